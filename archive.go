@@ -0,0 +1,209 @@
+package motivar
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// UnpackedMaxLength is the max size of the content after decompression, of
+// all files of an archive.
+const UnpackedMaxLength = 10 * BodyMaxLength
+
+// compression of the content.
+type compression struct {
+	extensions []string
+	// magic are the first bytes of the compressed content.
+	magic  []byte
+	reader func(r io.Reader) (io.Reader, error)
+}
+
+var compressions = []compression{
+	{
+		extensions: []string{".gz", ".tgz"},
+		magic:      []byte{0x1f, 0x8b},
+		reader:     func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+	},
+	{
+		extensions: []string{".bz2", ".tbz2"},
+		magic:      []byte("BZh"),
+		reader:     func(r io.Reader) (io.Reader, error) { return bzip2.NewReader(r), nil },
+	},
+	{
+		extensions: []string{".zst", ".tzst"},
+		magic:      []byte{0x28, 0xb5, 0x2f, 0xfd},
+		reader: func(r io.Reader) (io.Reader, error) {
+			decoder, err := zstd.NewReader(r)
+			if err != nil {
+				return nil, err
+			}
+			return decoder.IOReadCloser(), nil
+		},
+	},
+}
+
+// sourceFile is a file of the fetched content.
+type sourceFile struct {
+	name string
+	body []byte
+	// archived files came in a zip or tar archive.
+	archived bool
+}
+
+// unpacker reads the files of a content, keeping the size of all of them
+// below UnpackedMaxLength.
+type unpacker struct {
+	size int
+}
+
+// unpack returns the files of body, the content of the file name: body
+// itself, decompressed if needed, or the files of the archive.
+func (u *unpacker) unpack(name string, body []byte) ([]sourceFile, error) {
+	name, body, err := u.decompress(name, body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case strings.HasSuffix(strings.ToLower(name), ".zip") || bytes.HasPrefix(body, []byte("PK\x03\x04")):
+		return u.unzip(body)
+	case strings.HasSuffix(strings.ToLower(name), ".tar") || isTar(body):
+		return u.untar(body)
+	default:
+		return []sourceFile{{name: name, body: body}}, nil
+	}
+}
+
+// decompress returns body decompressed, if it's compressed, and name
+// without the extension of the compression. .tgz and similar become .tar.
+// The compression is known only by the first bytes of body: every gzip,
+// bzip2 and zstd stream starts with them, so the extension and the
+// Content-Encoding not decoded by the HTTP client are detected the same. They
+// aren't used as a fallback, since a body without the first bytes isn't
+// compressed, like a .gz served with Content-Encoding: gzip and decoded by
+// the HTTP client.
+func (u *unpacker) decompress(name string, body []byte) (string, []byte, error) {
+	ext := strings.ToLower(path.Ext(name))
+	for _, c := range compressions {
+		if slices.Contains(c.extensions, ext) {
+			name = name[:len(name)-len(ext)]
+			if strings.HasPrefix(ext, ".t") {
+				name += ".tar"
+			}
+		}
+		if !bytes.HasPrefix(body, c.magic) {
+			continue
+		}
+
+		reader, err := c.reader(bytes.NewReader(body))
+		if err != nil {
+			return "", nil, fmt.Errorf("decompressing %s: %w", name, err)
+		}
+		body, err = u.read(reader)
+		if closer, ok := reader.(io.Closer); ok {
+			closer.Close()
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("decompressing %s: %w", name, err)
+		}
+		return name, body, nil
+	}
+	return name, body, nil
+}
+
+func (u *unpacker) unzip(body []byte) ([]sourceFile, error) {
+	reader, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid zip archive: %w", err)
+	}
+
+	var files []sourceFile
+	for _, f := range reader.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("invalid zip archive: %s: %w", f.Name, err)
+		}
+		content, err := u.read(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("invalid zip archive: %s: %w", f.Name, err)
+		}
+
+		files, err = u.appendArchived(files, f.Name, content)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func (u *unpacker) untar(body []byte) ([]sourceFile, error) {
+	reader := tar.NewReader(bytes.NewReader(body))
+
+	var files []sourceFile
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return files, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid tar archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		content, err := u.read(reader)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tar archive: %s: %w", header.Name, err)
+		}
+		files, err = u.appendArchived(files, header.Name, content)
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// appendArchived appends the file of an archive, decompressed if needed, to
+// files. Archives inside archives aren't unpacked.
+func (u *unpacker) appendArchived(files []sourceFile, name string, body []byte) ([]sourceFile, error) {
+	name, body, err := u.decompress(name, body)
+	if err != nil {
+		return nil, err
+	}
+	return append(files, sourceFile{name: name, body: body, archived: true}), nil
+}
+
+// read reads r, counting its size in the limit of the unpacked content.
+func (u *unpacker) read(r io.Reader) ([]byte, error) {
+	// Read one byte more than the limit to know if the content exceeded it.
+	body, err := io.ReadAll(io.LimitReader(r, int64(UnpackedMaxLength-u.size+1)))
+	if err != nil {
+		return nil, err
+	}
+
+	u.size += len(body)
+	if u.size > UnpackedMaxLength {
+		return nil, fmt.Errorf("the unpacked content exceeded the limit (%v)", UnpackedMaxLength)
+	}
+	return body, nil
+}
+
+// isTar reports whether body is a tar archive, with the magic of the ustar
+// format.
+func isTar(body []byte) bool {
+	return len(body) > 262 && bytes.HasPrefix(body[257:], []byte("ustar"))
+}
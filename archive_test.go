@@ -0,0 +1,216 @@
+package motivar
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func readSample(t *testing.T, name string) []byte {
+	t.Helper()
+	body, err := os.ReadFile("samples/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func gzipped(t *testing.T, body []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(body); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func zstded(t *testing.T, body []byte) []byte {
+	t.Helper()
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer encoder.Close()
+	return encoder.EncodeAll(body, nil)
+}
+
+func zipped(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		f, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Write(files[name]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func tarred(t *testing.T, files map[string][]byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(files[name])), Typeflag: tar.TypeReg}
+		if err := w.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write(files[name]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestUnpack(t *testing.T) {
+	jsonl := readSample(t, "quotes-us.jsonl")
+	yaml := readSample(t, "quotes-us.yaml")
+	archived := map[string][]byte{
+		"quotes/us.jsonl":   jsonl,
+		"quotes/us.yaml.gz": gzipped(t, yaml),
+		"quotes/README.md":  []byte("# Quotes\n"),
+	}
+
+	tests := []struct {
+		name string
+		body []byte
+		want []sourceFile
+	}{
+		{"quotes.jsonl", jsonl, []sourceFile{{name: "quotes.jsonl", body: jsonl}}},
+		{"quotes.jsonl.gz", gzipped(t, jsonl), []sourceFile{{name: "quotes.jsonl", body: jsonl}}},
+		{"quotes.yaml.bz2", readSample(t, "quotes-us.yaml.bz2"), []sourceFile{{name: "quotes.yaml", body: yaml}}},
+		{"quotes.jsonl.zst", zstded(t, jsonl), []sourceFile{{name: "quotes.jsonl", body: jsonl}}},
+		// Content-Encoding not decoded by the HTTP client.
+		{"quotes", zstded(t, jsonl), []sourceFile{{name: "quotes", body: jsonl}}},
+		// A .gz served with Content-Encoding: gzip, already decoded by it.
+		{"quotes.jsonl.gz", jsonl, []sourceFile{{name: "quotes.jsonl", body: jsonl}}},
+		{"quotes.zip", zipped(t, archived), []sourceFile{
+			{name: "quotes/README.md", body: []byte("# Quotes\n"), archived: true},
+			{name: "quotes/us.jsonl", body: jsonl, archived: true},
+			{name: "quotes/us.yaml", body: yaml, archived: true},
+		}},
+		{"quotes.tgz", gzipped(t, tarred(t, archived)), []sourceFile{
+			{name: "quotes/README.md", body: []byte("# Quotes\n"), archived: true},
+			{name: "quotes/us.jsonl", body: jsonl, archived: true},
+			{name: "quotes/us.yaml", body: yaml, archived: true},
+		}},
+	}
+	for _, tt := range tests {
+		var u unpacker
+		files, err := u.unpack(tt.name, tt.body)
+		if err != nil {
+			t.Errorf("unpack(%s): %v", tt.name, err)
+			continue
+		}
+
+		equal := slices.EqualFunc(files, tt.want, func(a, b sourceFile) bool {
+			return a.name == b.name && bytes.Equal(a.body, b.body) && a.archived == b.archived
+		})
+		if !equal {
+			t.Errorf("unpack(%s): got %d files %v", tt.name, len(files), files)
+		}
+	}
+
+	var u unpacker
+	bomb := gzipped(t, make([]byte, UnpackedMaxLength+1))
+	if _, err := u.unpack("bomb.gz", bomb); err == nil {
+		t.Error("unpack beyond UnpackedMaxLength: want error")
+	}
+}
+
+func TestImportArchive(t *testing.T) {
+	archive := zipped(t, map[string][]byte{
+		"us.jsonl":  readSample(t, "quotes-us.jsonl"),
+		"us.toml":   readSample(t, "quotes-us.toml"),
+		"br.csv.gz": gzipped(t, []byte("author,phrase\nCora Coralina,O que vale na vida não é o ponto de partida e sim a caminhada.\n")),
+		"LICENSE":   []byte("MIT"),
+	})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/quotes.zip":
+			_, _ = w.Write(archive)
+		case "/quotes":
+			w.Header().Set("Content-Encoding", "zstd")
+			_, _ = w.Write(zstded(t, readSample(t, "quotes-us.yaml")))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := newTestClient(t, WithHTTPClient(server.Client()))
+	src := Source{URL: server.URL + "/quotes.zip", Format: FormatAuto, Language: LanguageAuto}
+
+	inserted, err := client.Import(ctx, src)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	// The jsonl and toml files have the same phrases.
+	if want := len(samplePhrases) + 1; inserted != want {
+		t.Errorf("Import: got %d phrases, want %d", inserted, want)
+	}
+
+	db, err := client.database(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var urls []string
+	rows, err := db.conn.QueryContext(ctx, "SELECT url FROM hashes ORDER BY url")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var url string
+		if err := rows.Scan(&url); err != nil {
+			t.Fatal(err)
+		}
+		urls = append(urls, url)
+	}
+	want := []string{src.URL + "#br.csv", src.URL + "#us.jsonl", src.URL + "#us.toml"}
+	if !slices.Equal(urls, want) {
+		t.Errorf("hashes: got %q, want %q", urls, want)
+	}
+
+	if _, err := client.Import(ctx, src); !errors.Is(err, ErrContentExists) {
+		t.Errorf("second Import: got %v, want ErrContentExists", err)
+	}
+
+	_, err = client.Import(ctx, Source{URL: src.URL, Format: "csv", Language: "br"})
+	if !errors.Is(err, ErrContentExists) {
+		t.Errorf("Import of the csv files: got %v, want ErrContentExists", err)
+	}
+
+	// The yaml phrases are the same of jsonl, so the content is new but the
+	// phrases aren't.
+	inserted, err = client.Import(ctx, Source{URL: server.URL + "/quotes", Format: FormatAuto, Language: "us"})
+	if err != nil || inserted != 0 {
+		t.Errorf("Import with Content-Encoding: got %d, %v", inserted, err)
+	}
+}
@@ -0,0 +1,387 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/wvoliveira/motivar"
+)
+
+// Exit codes of motivar.
+const (
+	exitOK    = 0
+	exitError = 1
+	// exitUsage is used when the command line is wrong: unknown command,
+	// flag or action, or missing arguments.
+	exitUsage = 2
+)
+
+// command is a subcommand of motivar.
+type command struct {
+	Name string
+	// Args shown after the name in the usage, like "<file>".
+	Args string
+	// Short is the one line description shown in the list of commands.
+	Short string
+	// Long is shown by the help of the command, after the usage line.
+	Long string
+	// Actions, if any, are the accepted values of the first argument. It's
+	// checked before Run.
+	Actions []string
+	// Flags declares the flags of the command. language is the default
+	// language resolved from the env, conf file and locale. The values are
+	// set to the variables read by Run only by calling bind, after parsing,
+	// so declaring the flags for help and completion changes nothing.
+	Flags func(fs *flag.FlagSet, language string) (bind func())
+	// Interspersed commands accept the flags after the args too.
+	Interspersed bool
+	// Run runs the command with the arguments left after the flags.
+	Run func(ctx context.Context, client *motivar.Client, args []string) error
+}
+
+// commands of motivar, in the order shown by help. The main command, which
+// shows a phrase, is rootCommand.
+var commands []*command
+
+func init() {
+	// Set here since help and completion read commands.
+	commands = []*command{
+		addPhrasesCommand,
+		importCommand,
+		exportCommand,
+		serveCommand,
+		serveQOTDCommand,
+		translateCommand,
+		dedupeCommand,
+		lintCommand,
+		dbCommand,
+		initCommand,
+		completionCommand,
+		helpCommand,
+	}
+}
+
+var helpCommand = &command{
+	Name:  "help",
+	Args:  "[command]",
+	Short: "Show the help of motivar or of a command",
+	Run:   runHelp,
+}
+
+// lookup returns the command called name, or nil.
+func lookup(name string) *command {
+	for _, c := range commands {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// flagSet returns the flag set of c, and the func setting the parsed values
+// to the variables read by Run. Errors are only returned by Parse, neither
+// printed nor handled by exiting.
+func (c *command) flagSet(language string) (*flag.FlagSet, func()) {
+	name := Name
+	if c != rootCommand {
+		name += " " + c.Name
+	}
+
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() {}
+	bind := func() {}
+	if c.Flags != nil {
+		bind = c.Flags(fs, language)
+	}
+	return fs, bind
+}
+
+// bindFlags returns the Flags of a command declaring its flags with declare
+// on a copy of target, set to target by bind.
+func bindFlags[T any](target *T, declare func(fs *flag.FlagSet, f *T, language string)) func(*flag.FlagSet, string) func() {
+	return func(fs *flag.FlagSet, language string) func() {
+		// A copy keeps the fields that aren't flags of the command, like the
+		// global flags in Flags.
+		f := new(T)
+		*f = *target
+		declare(fs, f, language)
+		return func() { *target = *f }
+	}
+}
+
+// printHelp writes the usage of c with its flags.
+func (c *command) printHelp(w io.Writer, fs *flag.FlagSet) {
+	if c == rootCommand {
+		printMainHelp(w, fs)
+		return
+	}
+
+	fmt.Fprintf(w, "Usage: %s %s", Name, c.Name)
+	if hasFlags(fs) {
+		fmt.Fprint(w, " [flags]")
+	}
+	if c.Args != "" {
+		fmt.Fprint(w, " "+c.Args)
+	}
+	fmt.Fprintf(w, "\n\n%s\n", c.Short)
+
+	if c.Long != "" {
+		fmt.Fprintf(w, "\n%s", c.Long)
+	}
+	if hasFlags(fs) {
+		fmt.Fprintf(w, "\nFlags:\n")
+		fs.SetOutput(w)
+		fs.PrintDefaults()
+	}
+	printGlobalFlags(w)
+}
+
+// printMainHelp writes the usage of motivar, with the list of commands.
+func printMainHelp(w io.Writer, fs *flag.FlagSet) {
+	fmt.Fprint(w, Banner)
+	fmt.Fprintf(w, "Usage: %s [flags]\n       %s <command> [flags] [args]\n\nShow a motivational phrase.\n\nFlags:\n", Name, Name)
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+
+	fmt.Fprintf(w, "\nCommands:\n")
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-12s %s\n", c.Name, c.Short)
+	}
+	printGlobalFlags(w)
+	fmt.Fprintf(w, "\nRun '%s help <command>' for the flags of a command.\n", Name)
+}
+
+func printGlobalFlags(w io.Writer) {
+	fmt.Fprintf(w, "\nGlobal flags, accepted in any position:\n")
+	fs := globalFlagSet(&Flags{})
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+}
+
+func hasFlags(fs *flag.FlagSet) bool {
+	has := false
+	fs.VisitAll(func(*flag.Flag) { has = true })
+	return has
+}
+
+// runHelp prints the help of the command in args, or of motivar.
+func runHelp(ctx context.Context, client *motivar.Client, args []string) error {
+	c := rootCommand
+	switch len(args) {
+	case 0:
+	case 1:
+		c = lookup(args[0])
+		if c == nil {
+			return unknownCommand(args[0])
+		}
+	default:
+		return usagef("help accepts only one command")
+	}
+
+	fs, _ := c.flagSet(defaultLanguage())
+	c.printHelp(os.Stdout, fs)
+	return nil
+}
+
+// usageError is an error in the command line.
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
+// usagef returns a usageError. It exits with exitUsage.
+func usagef(format string, a ...any) error {
+	return usageError{msg: fmt.Sprintf(format, a...)}
+}
+
+// unknownCommand returns the usage error of a command that doesn't exist,
+// suggesting the closest ones.
+func unknownCommand(name string) error {
+	msg := fmt.Sprintf("unknown command %q", name)
+	if s := suggest(name); len(s) > 0 {
+		msg += fmt.Sprintf(". Did you mean %s?", strings.Join(s, " or "))
+	}
+	return usageError{msg: msg}
+}
+
+// suggest returns the names of commands that look like name: starting with
+// it, or up to 2 edits away, but not by replacing all of it.
+func suggest(name string) []string {
+	var names []string
+	for _, c := range commands {
+		if strings.HasPrefix(c.Name, name) || motivar.Levenshtein(name, c.Name) <= min(2, len(name)-1) {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+// globalFlagSet returns the flags accepted by every command, set in f.
+func globalFlagSet(f *Flags) *flag.FlagSet {
+	fs := flag.NewFlagSet(Name, flag.ContinueOnError)
+	fs.BoolVar(&f.Debug, "debug", false, "Enable debug mode, the same as -log-level debug")
+	fs.StringVar(&f.Home, "home", "", "Folder with all motivar files, instead of the XDG folders (env MOTIVAR_HOME)")
+	fs.StringVar(&f.DB, "db", "", "Database file (env MOTIVAR_DB)")
+	fs.StringVar(&f.LogLevel, "log-level", "info", "Min level of the logs [debug,info,warn,error]")
+	fs.StringVar(&f.LogFormat, "log-format", "text", "Format of the logs [text,json]")
+	fs.BoolVar(&f.LogFile, "log-file", false, "Also write the logs to a rotating file in the state folder")
+	fs.BoolVar(&f.Safe, "safe", false, "Safe mode: skip phrases with profanity when importing and showing phrases, like safe = true in the [filter] section of motivar.ini")
+	return fs
+}
+
+// splitGlobalFlags removes the global flags from args, in any position, and
+// sets them in f.
+func splitGlobalFlags(args []string, f *Flags) ([]string, error) {
+	fs := globalFlagSet(f)
+	fs.SetOutput(io.Discard)
+
+	var rest, global []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			rest = append(rest, args[i:]...)
+			break
+		}
+
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		fl := fs.Lookup(name)
+		if fl == nil || !strings.HasPrefix(arg, "-") {
+			rest = append(rest, arg)
+			continue
+		}
+
+		global = append(global, arg)
+		if b, ok := fl.Value.(interface{ IsBoolFlag() bool }); hasValue || (ok && b.IsBoolFlag()) {
+			continue
+		}
+		if i+1 >= len(args) {
+			return nil, usagef("flag needs an argument: -%s", name)
+		}
+		i++
+		global = append(global, args[i])
+	}
+
+	if err := fs.Parse(global); err != nil {
+		return nil, usageError{msg: err.Error()}
+	}
+	return rest, nil
+}
+
+// exitCode reports err, if any, and returns the exit code for it.
+func exitCode(c *command, err error) int {
+	var usageErr usageError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &usageErr):
+		help := "help"
+		if c != nil && c != rootCommand && c != helpCommand {
+			help += " " + c.Name
+		}
+		fmt.Fprintf(os.Stderr, "%s: %v\nRun '%s %s' for usage.\n", Name, err, Name, help)
+		return exitUsage
+	case errors.Is(err, motivar.ErrContentExists):
+		logg.Warn("This content already exists in the database. Exiting...")
+		return exitError
+	default:
+		logg.Error(err.Error())
+		return exitError
+	}
+}
+
+// checkArgs returns a usage error if the number of args is not from least
+// to most.
+func checkArgs(args []string, least, most int) error {
+	if len(args) < least {
+		return usagef("missing arguments")
+	}
+	if len(args) > most {
+		return usagef("unexpected arguments: %s", strings.Join(args[most:], " "))
+	}
+	return nil
+}
+
+// listFlag is a flag that can be repeated or have comma-separated values.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	if l == nil {
+		return ""
+	}
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+	return nil
+}
+
+// repeatedFlag is a flag that can be repeated.
+type repeatedFlag []string
+
+func (r *repeatedFlag) String() string {
+	if r == nil {
+		return ""
+	}
+	return strings.Join(*r, " ")
+}
+
+func (r *repeatedFlag) Set(value string) error {
+	*r = append(*r, value)
+	return nil
+}
+
+// parseInterspersed parses the flags of fs in args, also after the other
+// args, and returns the other args. Args after "--" are never flags.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var rest []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+
+		left := fs.Args()
+		if len(left) == 0 {
+			return rest, nil
+		}
+		if len(left) < len(args) && args[len(args)-len(left)-1] == "--" {
+			return append(rest, left...), nil
+		}
+		rest = append(rest, left[0])
+		args = left[1:]
+	}
+}
+
+// checkLanguage returns a usage error if lang is not supported.
+func checkLanguage(lang string) error {
+	if err := CheckLanguages(lang); err != nil {
+		return usageError{msg: err.Error()}
+	}
+	return nil
+}
+
+// checkAction returns a usage error if the first arg is not one of c.Actions.
+func checkAction(c *command, args []string) error {
+	use := strings.Join(c.Actions, ", ")
+	if len(args) == 0 {
+		return usagef("missing action. Use %s", use)
+	}
+	if !slices.Contains(c.Actions, args[0]) {
+		return usagef("unknown action %q. Use %s", args[0], use)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSuggest(t *testing.T) {
+	tests := map[string][]string{
+		"servr":     {"serve"},
+		"sevre":     {"serve"},
+		"serve-qtd": {"serve-qotd"},
+		"comp":      {"completion"},
+		"x":         nil,
+		"quote":     nil,
+		"exprot":    {"export"},
+	}
+	for name, want := range tests {
+		if got := suggest(name); !slices.Equal(got, want) {
+			t.Errorf("suggest(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestExitCodes(t *testing.T) {
+	t.Setenv("MOTIVAR_HOME", t.TempDir())
+
+	tests := []struct {
+		args []string
+		want int
+	}{
+		{[]string{"-l", "us"}, exitOK},
+		{[]string{"help", "db"}, exitOK},
+		{[]string{"db", "-h"}, exitOK},
+		{[]string{"db", "check", "-debug"}, exitOK},
+		{[]string{"servr"}, exitUsage},
+		{[]string{"-l", "xx"}, exitUsage},
+		{[]string{"-nope"}, exitUsage},
+		{[]string{"db"}, exitUsage},
+		{[]string{"db", "foo"}, exitUsage},
+		{[]string{"translate", "link", "1"}, exitUsage},
+		{[]string{"add-phrases", "-fmt", "csv"}, exitUsage},
+		{[]string{"translate", "link", "1", "2"}, exitError},
+		{[]string{"import", "wikiquote", "dump.xml"}, exitUsage},
+		{[]string{"import", "wikiquote", "../../samples/wikiquote-pt.xml", "-language", "br", "-category", "escritores"}, exitOK},
+		{[]string{"import", "wikiquote", "missing.xml", "-language", "br"}, exitError},
+	}
+	for _, tt := range tests {
+		flags = Flags{}
+		if got := run(tt.args); got != tt.want {
+			t.Errorf("run(%q) = %d, want %d", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestParseInterspersed(t *testing.T) {
+	var (
+		language string
+		debug    bool
+	)
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&language, "language", "", "")
+	fs.BoolVar(&debug, "v", false, "")
+
+	args, err := parseInterspersed(fs, []string{"a", "-language", "br", "b", "-v", "--", "-c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b", "-c"}; !slices.Equal(args, want) || language != "br" || !debug {
+		t.Errorf("got %q, %q, %v; want %q, br, true", args, language, debug, want)
+	}
+
+	if _, err := parseInterspersed(fs, []string{"a", "-nope"}); err == nil {
+		t.Error("unknown flag: want error")
+	}
+}
+
+func TestInterspersedFlags(t *testing.T) {
+	t.Setenv("MOTIVAR_HOME", t.TempDir())
+
+	// Flags before the args are kept when the ones after are parsed.
+	for _, args := range [][]string{
+		{"init", "-once-per", "4h", "bash"},
+		{"init", "bash", "-once-per", "4h"},
+	} {
+		flags = Flags{}
+		out := captureStdout(t, func() {
+			if got := run(args); got != exitOK {
+				t.Fatalf("run(%q) = %d, want %d", args, got, exitOK)
+			}
+		})
+		if !strings.Contains(out, "motivar -once-per 4h\n") {
+			t.Errorf("run(%q): snippet without -once-per 4h", args)
+		}
+	}
+}
+
+func TestListingFlagsKeepsValues(t *testing.T) {
+	flags = Flags{Language: "us", MaxLength: 40, Debug: true}
+	flagsInit = FlagsInit{OncePer: time.Hour}
+	t.Cleanup(func() {
+		flags = Flags{}
+		flagsInit = FlagsInit{}
+	})
+
+	// Help and completion declare the flags again, with their defaults.
+	newCompletionSpec()
+	for _, c := range append(commands, rootCommand) {
+		fs, _ := c.flagSet("br")
+		c.printHelp(io.Discard, fs)
+	}
+	if flags.Language != "us" || flags.MaxLength != 40 || flagsInit.OncePer != time.Hour {
+		t.Errorf("listing the flags changed them: %+v, %+v", flags, flagsInit)
+	}
+
+	fs, bind := rootCommand.flagSet("br")
+	if err := fs.Parse([]string{"-max-length", "20"}); err != nil {
+		t.Fatal(err)
+	}
+	bind()
+	if flags.Language != "br" || flags.MaxLength != 20 || !flags.Debug {
+		t.Errorf("bind: got %+v, want the parsed flags and the global ones kept", flags)
+	}
+}
+
+// captureStdout returns what fn writes to os.Stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	done := make(chan []byte)
+	go func() {
+		out, _ := io.ReadAll(r)
+		done <- out
+	}()
+
+	fn()
+	w.Close()
+	return string(<-done)
+}
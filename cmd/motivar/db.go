@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/wvoliveira/motivar"
+)
+
+var dbCommand = &command{
+	Name:  "db",
+	Args:  "<action> [file]",
+	Short: "Back up, restore, check or vacuum the database",
+	Long: `Actions:
+  backup <file>    Save a copy of the database, while it's in use
+  restore <file>   Replace the database with a backup
+  check            Check the integrity of the database
+  vacuum           Rebuild the database to reclaim unused space
+`,
+	Actions: []string{"backup", "restore", "check", "vacuum"},
+	Run:     db,
+}
+
+// db runs the db command with args after "db".
+func db(ctx context.Context, client *motivar.Client, args []string) error {
+	action, args := args[0], args[1:]
+	switch action {
+	case "backup", "restore":
+		if len(args) != 1 {
+			return usagef("usage: db %s <file>", action)
+		}
+
+		var (
+			stats motivar.DBStats
+			err   error
+		)
+		if action == "backup" {
+			stats, err = client.Backup(ctx, args[0])
+		} else {
+			stats, err = client.Restore(ctx, args[0])
+		}
+		if err != nil {
+			return err
+		}
+
+		printStats(stats)
+		return nil
+	case "check":
+		if err := checkArgs(args, 0, 0); err != nil {
+			return err
+		}
+		stats, err := client.Stats(ctx)
+		if err != nil {
+			return err
+		}
+		printStats(stats)
+
+		problems, err := client.Check(ctx)
+		if err != nil {
+			return err
+		}
+		for _, problem := range problems {
+			fmt.Println("  " + problem)
+		}
+		if len(problems) > 0 {
+			return fmt.Errorf("%d problems found", len(problems))
+		}
+
+		fmt.Println("OK, no problems found.")
+		return nil
+	case "vacuum":
+		if err := checkArgs(args, 0, 0); err != nil {
+			return err
+		}
+		before, after, err := client.Vacuum(ctx)
+		if err != nil {
+			return err
+		}
+
+		printStats(after)
+		// The log of the vacuum may leave the files bigger.
+		switch reclaimed := before.Size - after.Size; {
+		case reclaimed > 0:
+			fmt.Printf("Reclaimed %s.\n", formatBytes(reclaimed))
+		case reclaimed < 0:
+			fmt.Printf("Nothing reclaimed, the files grew %s.\n", formatBytes(-reclaimed))
+		default:
+			fmt.Println("Nothing reclaimed.")
+		}
+	}
+	return nil
+}
+
+func printStats(s motivar.DBStats) {
+	fmt.Printf("%s: %s, schema version %d, %d phrases, %d sources, %d translations\n",
+		s.Path, formatBytes(s.Size), s.SchemaVersion, s.Phrases, s.Sources, s.Translations)
+}
+
+// formatBytes formats n bytes like 1.5 MiB.
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
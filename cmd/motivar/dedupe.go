@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/wvoliveira/motivar"
+)
+
+type FlagsDedupe struct {
+	Language  string
+	Auto      bool
+	Threshold float64
+}
+
+var dedupeCommand = &command{
+	Name:  "dedupe",
+	Short: "Find and merge near-duplicate phrases",
+	Long: `Phrases are grouped with their similarity, and you choose which one of each
+group to keep. Embedded phrases are never removed.
+`,
+	Flags: bindFlags(&flagsDedupe, func(fs *flag.FlagSet, f *FlagsDedupe, language string) {
+		fs.StringVar(&f.Language, "l", "", "Only phrases of this language [br,us]. Default is all languages")
+		fs.BoolVar(&f.Auto, "auto", false, "Merge all groups keeping the first phrase, without asking")
+		fs.Float64Var(&f.Threshold, "threshold", 0.9, "Min similarity, from 0 to 1, of duplicates")
+	}),
+	Run: func(ctx context.Context, client *motivar.Client, args []string) error {
+		if err := checkArgs(args, 0, 0); err != nil {
+			return err
+		}
+		if flagsDedupe.Language != "" {
+			if err := checkLanguage(flagsDedupe.Language); err != nil {
+				return err
+			}
+		}
+		return dedupeStdio(ctx, client, flagsDedupe)
+	},
+}
+
+// dedupe shows the groups of near-duplicate phrases and merges them, asking
+// which phrase to keep unless auto is set.
+func dedupe(ctx context.Context, client *motivar.Client, f FlagsDedupe, in io.Reader, out io.Writer) error {
+	languages := motivar.Languages()
+	if f.Language != "" {
+		languages = []string{f.Language}
+	}
+
+	reader := bufio.NewReader(in)
+	var groups, removed int
+
+	for _, language := range languages {
+		found, err := client.FindDuplicates(ctx, motivar.Options{Language: language}, f.Threshold)
+		if err != nil {
+			return err
+		}
+
+		for _, group := range found {
+			groups++
+			_, _ = fmt.Fprintf(out, "\nGroup %d [%s]:\n", groups, language)
+			for i, p := range group.Phrases {
+				_, _ = fmt.Fprintf(out, "  %d) %.2f %s\n", i+1, group.Scores[i], formatPhrase(p))
+			}
+
+			keep := 0
+			if !f.Auto {
+				_, _ = fmt.Fprintf(out, "Keep which? [1-%d, s=skip, q=quit] (default 1): ", len(group.Phrases))
+				answer, err := reader.ReadString('\n')
+				answer = strings.TrimSpace(answer)
+				if answer == "q" || (err != nil && answer == "") {
+					_, _ = fmt.Fprintln(out)
+					return report(out, groups, removed)
+				}
+				if answer == "s" {
+					continue
+				}
+				if answer != "" {
+					keep, err = strconv.Atoi(answer)
+					if err != nil || keep < 1 || keep > len(group.Phrases) {
+						_, _ = fmt.Fprintf(out, "Invalid choice %q, skipping.\n", answer)
+						continue
+					}
+					keep--
+				}
+			}
+
+			n, err := client.Merge(ctx, group.Phrases[keep], group.Phrases)
+			if err != nil {
+				return err
+			}
+			removed += n
+		}
+	}
+
+	return report(out, groups, removed)
+}
+
+func report(out io.Writer, groups, removed int) error {
+	_, err := fmt.Fprintf(out, "%d groups of duplicates found, %d phrases removed.\n", groups, removed)
+	return err
+}
+
+// dedupeStdio runs dedupe with the terminal.
+func dedupeStdio(ctx context.Context, client *motivar.Client, f FlagsDedupe) error {
+	return dedupe(ctx, client, f, os.Stdin, os.Stdout)
+}
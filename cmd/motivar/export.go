@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/wvoliveira/motivar"
+)
+
+type FlagsExport struct {
+	Format   string
+	Language string
+}
+
+var exportCommand = &command{
+	Name:  "export",
+	Args:  "[file]",
+	Short: "Write the phrases of a language to a file",
+	Long: `Without file, the phrases are written to stdout. With -fmt fortune, the
+strfile index is written to <file>.dat too, so the file can be read by
+fortune(6).
+`,
+	Flags: bindFlags(&flagsExport, func(fs *flag.FlagSet, f *FlagsExport, language string) {
+		fs.StringVar(&f.Format, "fmt", "json", "Format of the file ["+strings.Join(motivar.ExportFormats(), ",")+"]")
+		fs.StringVar(&f.Language, "l", language, "Language of the phrases [br,us]")
+	}),
+	Run: func(ctx context.Context, client *motivar.Client, args []string) error {
+		if err := checkArgs(args, 0, 1); err != nil {
+			return err
+		}
+		if err := checkLanguage(flagsExport.Language); err != nil {
+			return err
+		}
+		if !slices.Contains(motivar.ExportFormats(), flagsExport.Format) {
+			return usagef("format %q can't be exported. Use %s", flagsExport.Format, strings.Join(motivar.ExportFormats(), ", "))
+		}
+
+		opts := motivar.Options{Language: flagsExport.Language}
+		if len(args) == 0 {
+			return client.Export(ctx, os.Stdout, flagsExport.Format, opts)
+		}
+		return exportFile(ctx, client, args[0], flagsExport.Format, opts)
+	},
+}
+
+// exportFile writes the phrases to the file at path and, for fortune, its
+// index to path.dat.
+func exportFile(ctx context.Context, client *motivar.Client, path, format string, opts motivar.Options) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := client.Export(ctx, file, format, opts); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	if format == "fortune" {
+		if err := writeStrfile(path); err != nil {
+			return fmt.Errorf("writing the index of %s: %w", path, err)
+		}
+	}
+	logg.Info(fmt.Sprintf("Phrases written to %s", path))
+	return nil
+}
+
+func writeStrfile(path string) error {
+	fortune, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fortune.Close()
+
+	dat, err := os.Create(path + ".dat")
+	if err != nil {
+		return err
+	}
+	if err := motivar.WriteStrfile(dat, fortune); err != nil {
+		dat.Close()
+		return err
+	}
+	return dat.Close()
+}
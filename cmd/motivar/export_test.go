@@ -0,0 +1,34 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExportFortune(t *testing.T) {
+	t.Setenv("MOTIVAR_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "motivar")
+
+	flags = Flags{}
+	if got := run([]string{"export", "-fmt", "fortune", "-l", "us", path}); got != exitOK {
+		t.Fatalf("run: got %d, want %d", got, exitOK)
+	}
+
+	fortune, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dat, err := os.Stat(path + ".dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fortune) == 0 || dat.Size() <= 24 {
+		t.Errorf("got %d bytes in the file and %d in the index", len(fortune), dat.Size())
+	}
+
+	flags = Flags{}
+	if got := run([]string{"export", "-fmt", "yaml"}); got != exitUsage {
+		t.Errorf("export yaml: got %d, want %d", got, exitUsage)
+	}
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/wvoliveira/motivar"
+)
+
+type FlagsImport struct {
+	Language   string
+	Pages      listFlag
+	Categories listFlag
+	MinLength  int
+	MaxLength  int
+}
+
+var importCommand = &command{
+	Name:  "import",
+	Args:  "<source> <file>",
+	Short: "Import phrases from an offline dump of a site",
+	Long: `Sources:
+  wikiquote <file>   Wikiquote dump (.xml or .xml.bz2), like
+                     ptwikiquote-latest-pages-articles.xml.bz2 from
+                     https://dumps.wikimedia.org. The title of each page is
+                     the author of its quotes.
+
+The flags can come after the file too.
+`,
+	Actions: []string{"wikiquote"},
+	Flags: bindFlags(&flagsImport, func(fs *flag.FlagSet, f *FlagsImport, language string) {
+		fs.StringVar(&f.Language, "language", "", "The language of phrases [br,us], or auto to detect it per phrase")
+		fs.Var(&f.Pages, "page", "Import only the pages with this title. Can be repeated or comma-separated")
+		fs.Var(&f.Categories, "category", "Import only the pages in a category containing this text. Can be repeated or comma-separated")
+		fs.IntVar(&f.MinLength, "min-length", 10, "Min length of the phrases, in characters")
+		fs.IntVar(&f.MaxLength, "max-length", 300, "Max length of the phrases, in characters. 0 is no limit")
+	}),
+	Interspersed: true,
+	Run: func(ctx context.Context, client *motivar.Client, args []string) error {
+		if err := checkArgs(args[1:], 1, 1); err != nil {
+			return err
+		}
+		if flagsImport.Language == "" {
+			return usagef("-language is required")
+		}
+		if flagsImport.Language != motivar.LanguageAuto {
+			if err := checkLanguage(flagsImport.Language); err != nil {
+				return err
+			}
+		}
+
+		_, err := client.ImportWikiquote(ctx, args[1], motivar.WikiquoteOptions{
+			Language:   flagsImport.Language,
+			Pages:      flagsImport.Pages,
+			Categories: flagsImport.Categories,
+			MinLength:  flagsImport.MinLength,
+			MaxLength:  flagsImport.MaxLength,
+		})
+		return err
+	},
+}
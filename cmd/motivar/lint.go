@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/wvoliveira/motivar"
+)
+
+type FlagsLint struct {
+	Language string
+	Unsafe   bool
+}
+
+var lintCommand = &command{
+	Name:  "lint",
+	Short: "List problems of the phrases in the database",
+	Long: `Checks:
+  -unsafe   Phrases with profanity or offensive words, blocked by the content
+            filter in safe mode. The words are the built-in ones with the
+            block and allow lists of the [filter] section of motivar.ini.
+
+Without flags, all checks run. The exit code is 1 when problems are found.
+`,
+	Flags: bindFlags(&flagsLint, func(fs *flag.FlagSet, f *FlagsLint, language string) {
+		fs.StringVar(&f.Language, "l", "", "Only phrases of this language [br,us]. Default is all languages")
+		fs.BoolVar(&f.Unsafe, "unsafe", false, "List the phrases blocked by the content filter")
+	}),
+	Run: func(ctx context.Context, client *motivar.Client, args []string) error {
+		if err := checkArgs(args, 0, 0); err != nil {
+			return err
+		}
+		if flagsLint.Language != "" {
+			if err := checkLanguage(flagsLint.Language); err != nil {
+				return err
+			}
+		}
+		filter, _, err := cfg.ContentFilter()
+		if err != nil {
+			return err
+		}
+		return lint(ctx, client, flagsLint, filter, os.Stdout)
+	},
+}
+
+// lint writes the problems of the database phrases to w, and returns an
+// error if there is any.
+func lint(ctx context.Context, client *motivar.Client, f FlagsLint, filter motivar.ContentFilter, w io.Writer) error {
+	languages := motivar.Languages()
+	if f.Language != "" {
+		languages = []string{f.Language}
+	}
+	// Without flags, all checks run.
+	all := !f.Unsafe
+
+	var problems int
+	if f.Unsafe || all {
+		n, err := lintUnsafe(ctx, client, languages, filter, w)
+		if err != nil {
+			return err
+		}
+		problems += n
+	}
+
+	if problems > 0 {
+		return fmt.Errorf("%d problems found", problems)
+	}
+	fmt.Fprintln(w, "OK, no problems found.")
+	return nil
+}
+
+// lintUnsafe writes the phrases of languages blocked by filter, and returns
+// how many there are.
+func lintUnsafe(ctx context.Context, client *motivar.Client, languages []string, filter motivar.ContentFilter, w io.Writer) (int, error) {
+	var n int
+	for _, language := range languages {
+		unsafe, err := client.UnsafePhrases(ctx, motivar.Options{Language: language}, filter)
+		if err != nil {
+			return n, err
+		}
+		for _, p := range unsafe {
+			fmt.Fprintf(w, "%d\t%s\tunsafe (%s)\t%s\n", p.ID, p.Language, strings.Join(p.Words, ", "), formatPhrase(p.Phrase))
+		}
+		n += len(unsafe)
+	}
+	return n, nil
+}
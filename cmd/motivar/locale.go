@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"strings"
+)
+
+// localeLanguages maps locales to languages of phrases. A locale is looked up
+// with its territory (pt_BR) and then only by its language (pt).
+var localeLanguages = map[string]string{
+	"pt_BR": "br",
+	"pt":    "br",
+	"en_US": "us",
+	"en":    "us",
+}
+
+// localeVariables in order of precedence.
+var localeVariables = []string{"LC_ALL", "LC_MESSAGES", "LANG"}
+
+// defaultLanguage returns the language used when the -l flag is not set: the
+// MOTIVAR_LANGUAGE environment variable, the conf file, the system locale or
+// "br", in this order. Unsupported values are ignored.
+func defaultLanguage() string {
+	if lang := os.Getenv("MOTIVAR_LANGUAGE"); CheckLanguages(lang) == nil {
+		return lang
+	}
+	if lang := cfg.Language(); lang != "" {
+		return lang
+	}
+	if lang := localeLanguage(os.Getenv); lang != "" {
+		return lang
+	}
+	return "br"
+}
+
+// localeLanguage returns the language of the first locale variable mapped to
+// a supported language, or "" if there is none.
+func localeLanguage(getenv func(string) string) string {
+	for _, name := range localeVariables {
+		if lang := languageOfLocale(getenv(name)); lang != "" {
+			return lang
+		}
+	}
+	return ""
+}
+
+// languageOfLocale converts a locale like pt_BR.UTF-8 or en_US@euro to a
+// supported language, or "" if it can't be mapped.
+func languageOfLocale(locale string) string {
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	locale = strings.ReplaceAll(locale, "-", "_")
+
+	language, territory, _ := strings.Cut(locale, "_")
+	language = strings.ToLower(language)
+
+	candidates := []string{language}
+	if territory != "" {
+		candidates = []string{language + "_" + strings.ToUpper(territory), language}
+	}
+
+	for _, candidate := range candidates {
+		lang, ok := localeLanguages[candidate]
+		if ok && CheckLanguages(lang) == nil {
+			return lang
+		}
+	}
+	return ""
+}
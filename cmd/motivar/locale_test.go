@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestLanguageOfLocale(t *testing.T) {
+	locales := []struct {
+		locale string
+		lang   string
+	}{
+		{"pt_BR.UTF-8", "br"},
+		{"pt_PT.UTF-8", "br"},
+		{"pt-br", "br"},
+		{"en_US", "us"},
+		{"en_GB.UTF-8", "us"},
+		{"en_US.ISO-8859-1@euro", "us"},
+		{"C", ""},
+		{"POSIX", ""},
+		{"fr_FR.UTF-8", ""},
+		{"", ""},
+	}
+
+	for _, l := range locales {
+		if got := languageOfLocale(l.locale); got != l.lang {
+			t.Errorf("Locale %q: got %q, want %q.", l.locale, got, l.lang)
+		}
+	}
+}
+
+func TestLocaleLanguage(t *testing.T) {
+	envs := []struct {
+		env  map[string]string
+		lang string
+	}{
+		{map[string]string{"LANG": "en_US.UTF-8"}, "us"},
+		{map[string]string{"LC_ALL": "pt_BR.UTF-8", "LANG": "en_US.UTF-8"}, "br"},
+		{map[string]string{"LC_MESSAGES": "en_US", "LANG": "pt_BR"}, "us"},
+		{map[string]string{"LC_ALL": "C.UTF-8", "LANG": "en_US.UTF-8"}, "us"},
+		{map[string]string{"LANG": "de_DE"}, ""},
+		{map[string]string{}, ""},
+	}
+
+	for _, e := range envs {
+		getenv := func(name string) string { return e.env[name] }
+		if got := localeLanguage(getenv); got != e.lang {
+			t.Errorf("Environment %v: got %q, want %q.", e.env, got, e.lang)
+		}
+	}
+}
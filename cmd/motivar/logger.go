@@ -0,0 +1,175 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Log formats of -log-format.
+var logFormats = []string{"text", "json"}
+
+const (
+	// logFileName is the log file in the state folder, with -log-file.
+	logFileName = "motivar.log"
+	// logMaxSize is the size of the log file that makes it rotate.
+	logMaxSize = 1 << 20
+	// logBackups is how many rotated files are kept, as motivar.log.1 (the
+	// newest) to motivar.log.3.
+	logBackups = 3
+)
+
+// NewLogger returns a logger writing to w in format, text or json, the logs
+// of level or above.
+func NewLogger(w io.Writer, format string, level slog.Leveler) (*slog.Logger, error) {
+	opts := &slog.HandlerOptions{
+		AddSource: true,
+		Level:     level,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.SourceKey {
+				s := a.Value.Any().(*slog.Source)
+				s.File = path.Base(s.File)
+			}
+
+			if a.Key == slog.TimeKey && format == "text" {
+				t := a.Value.Time()
+				a.Value = slog.StringValue(t.Format(time.DateTime))
+			}
+			return a
+		},
+	}
+
+	switch format {
+	case "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("log format %q not supported. Use %s", format, strings.Join(logFormats, " or "))
+	}
+}
+
+// parseLevel parses the name of a level: debug, info, warn or error.
+func parseLevel(name string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(name)); err != nil {
+		return level, fmt.Errorf("log level %q not supported. Use debug, info, warn or error", name)
+	}
+	return level, nil
+}
+
+// setupLogger sets logg from the global flags. Logs go to stderr and, if
+// file isn't nil, to it too.
+func setupLogger(f Flags, file io.Writer) error {
+	level, err := parseLevel(f.LogLevel)
+	if err != nil {
+		return err
+	}
+	if f.Debug {
+		level = slog.LevelDebug
+	}
+
+	var w io.Writer = os.Stderr
+	if file != nil {
+		w = io.MultiWriter(os.Stderr, file)
+	}
+
+	logger, err := NewLogger(w, f.LogFormat, level)
+	if err != nil {
+		return err
+	}
+	logg = logger
+	return nil
+}
+
+// rotatingFile appends to a file, renaming it to a backup when it would grow
+// beyond maxSize.
+type rotatingFile struct {
+	path    string
+	maxSize int64
+	backups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// openRotatingFile opens, or creates, the file at path.
+func openRotatingFile(path string, maxSize int64, backups int) (*rotatingFile, error) {
+	r := &rotatingFile{path: path, maxSize: maxSize, backups: backups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *rotatingFile) open() error {
+	err := os.MkdirAll(filepath.Dir(r.path), 0764)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotate renames the file to path.1, path.1 to path.2 and so on, removing
+// the oldest one, and opens a new file.
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+
+	for i := r.backups; i > 0; i-- {
+		from := r.path
+		if i > 1 {
+			from = fmt.Sprintf("%s.%d", r.path, i-1)
+		}
+
+		// Another process may have rotated it already.
+		err := os.Rename(from, fmt.Sprintf("%s.%d", r.path, i))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return r.open()
+}
+
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := NewLogger(&buf, "json", slog.LevelWarn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger.Info("hidden")
+	logger.Warn("shown")
+
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("log isn't one JSON object: %v\n%s", err, buf.String())
+	}
+	if entry["msg"] != "shown" || entry["level"] != "WARN" {
+		t.Errorf("entry: got %v", entry)
+	}
+
+	if _, err := NewLogger(&buf, "xml", slog.LevelInfo); err == nil {
+		t.Error("NewLogger xml: want error")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for name, want := range map[string]slog.Level{
+		"debug": slog.LevelDebug,
+		"INFO":  slog.LevelInfo,
+		"warn":  slog.LevelWarn,
+		"error": slog.LevelError,
+	} {
+		got, err := parseLevel(name)
+		if err != nil || got != want {
+			t.Errorf("parseLevel(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+
+	if _, err := parseLevel("verbose"); err == nil {
+		t.Error("parseLevel verbose: want error")
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", logFileName)
+	file, err := openRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := map[string]string{
+		path:        "fourth\n",
+		path + ".1": "third\n",
+		path + ".2": "second\n",
+	}
+	for name, content := range want {
+		got, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != content {
+			t.Errorf("%s: got %q, want %q", filepath.Base(name), got, content)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("more backups than allowed: %v", err)
+	}
+}
+
+func TestLogFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("MOTIVAR_HOME", home)
+
+	flags = Flags{}
+	code := run([]string{"translate", "-log-file", "link", "1", "2", "-log-format", "json"})
+	if code != exitError {
+		t.Fatalf("run: exit code %d, want %d", code, exitError)
+	}
+
+	content, err := os.ReadFile(filepath.Join(home, "state", "logs", logFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(content), "{") || !strings.Contains(string(content), "phrase not found") {
+		t.Errorf("log file doesn't have the JSON error:\n%s", content)
+	}
+}
@@ -0,0 +1,459 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/wvoliveira/motivar"
+	"gopkg.in/ini.v1"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	Name    = "motivar"
+	version = "v0.1.0"
+)
+
+var (
+	// Banner to show when run flags
+	Banner = fmt.Sprintf(`
+             ._ o o
+             \_´-)|_
+          ,""       \
+        ,"  ## |   ಠ ಠ. 
+      ," ##   ,-\__    ´.
+    ,"       /     ´--._;)
+  ,"     ## / Motivar %v
+,"   ##    /
+
+`, version)
+)
+
+// Conf directory/file struct
+type Conf struct {
+	Dir      string
+	File     string
+	DataDir  string
+	StateDir string
+	DB       string
+}
+
+type Flags struct {
+	Language  string
+	Debug     bool
+	Home      string
+	DB        string
+	OncePer   time.Duration
+	LogLevel  string
+	LogFormat string
+	LogFile   bool
+	Safe      bool
+	// Length of the phrases shown.
+	MinLength  int
+	MaxLength  int
+	LengthUnit string
+	Truncate   bool
+}
+
+type FlagsAdd struct {
+	Format    string
+	URL       string
+	Language  string
+	AuthorSep string
+	Patterns  repeatedFlag
+	SHA256    string
+	Signature string
+}
+
+var (
+	cfg         Conf
+	flags       Flags
+	flagsAdd    FlagsAdd
+	flagsServe  FlagsServe
+	flagsQOTD   FlagsQOTD
+	flagsDedupe FlagsDedupe
+	flagsLint   FlagsLint
+	flagsExport FlagsExport
+	flagsImport FlagsImport
+	flagsInit   FlagsInit
+	logg        *slog.Logger
+	// storageErr is the error creating the files of motivar, if any.
+	storageErr error
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+// run runs the command line args and returns the exit code.
+func run(args []string) int {
+	args, err := splitGlobalFlags(args, &flags)
+	if err == nil {
+		err = setupLogger(flags, nil)
+	}
+	if err != nil {
+		return exitCode(nil, usageError{msg: err.Error()})
+	}
+
+	// Without the files, phrases still come from the embedded corpus.
+	storageErr = resolveConf(flags.Home, flags.DB)
+	if storageErr == nil {
+		storageErr = cfg.Setup()
+	}
+
+	if flags.LogFile && storageErr == nil {
+		file, err := openRotatingFile(filepath.Join(cfg.StateDir, "logs", logFileName), logMaxSize, logBackups)
+		if err != nil {
+			logg.Warn(fmt.Sprintf("Opening log file: %v", err))
+		} else {
+			defer file.Close()
+			_ = setupLogger(flags, file)
+		}
+	}
+
+	cmd := rootCommand
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		cmd = lookup(args[0])
+		if cmd == nil {
+			return exitCode(nil, unknownCommand(args[0]))
+		}
+		args = args[1:]
+	}
+
+	// Read the env, conf file and locale once for all flags.
+	fs, bind := cmd.flagSet(defaultLanguage())
+	if cmd.Interspersed {
+		args, err = parseInterspersed(fs, args)
+	} else {
+		err = fs.Parse(args)
+		args = fs.Args()
+	}
+	if errors.Is(err, flag.ErrHelp) {
+		cmd.printHelp(os.Stdout, fs)
+		return exitOK
+	}
+	if err != nil {
+		return exitCode(cmd, usageError{msg: err.Error()})
+	}
+	bind()
+
+	if len(cmd.Actions) > 0 {
+		if err = checkAction(cmd, args); err != nil {
+			return exitCode(cmd, err)
+		}
+	}
+
+	client, err := initDatabase()
+	if err != nil {
+		return exitCode(cmd, err)
+	}
+	defer client.Close()
+
+	err = cmd.Run(context.Background(), client, args)
+	return exitCode(cmd, err)
+}
+
+var rootCommand = &command{
+	Name: Name,
+	Flags: bindFlags(&flags, func(fs *flag.FlagSet, f *Flags, language string) {
+		fs.StringVar(&f.Language, "l", language, "Choose a language to show quotes [br,us], or two like br,us to show the translation")
+		fs.DurationVar(&f.OncePer, "once-per", 0, "Show a phrase at most once in this interval, like 4h. Used when opening shells")
+		fs.IntVar(&f.MinLength, "min-length", 0, "Min length of the phrase, in -length-unit. 0 is no limit")
+		fs.IntVar(&f.MaxLength, "max-length", 0, "Max length of the phrase, in -length-unit. 0 is no limit")
+		fs.StringVar(&f.LengthUnit, "length-unit", motivar.LengthCharacters, "Unit of -min-length and -max-length ["+motivar.LengthCharacters+","+motivar.LengthWords+"]")
+		fs.BoolVar(&f.Truncate, "truncate", false, "Shorten phrases longer than -max-length on a word boundary, with an ellipsis, instead of skipping them")
+	}),
+	Run: showPhrase,
+}
+
+// showPhrase prints a random phrase, and its translation if two languages
+// are given.
+func showPhrase(ctx context.Context, client *motivar.Client, args []string) error {
+	if len(args) > 0 {
+		return unknownCommand(args[0])
+	}
+
+	languages := strings.Split(flags.Language, ",")
+	for _, lang := range languages {
+		if err := checkLanguage(lang); err != nil {
+			return err
+		}
+	}
+	if len(languages) > 2 {
+		return usagef("choose one language, or two to show the translation")
+	}
+	opts, err := phraseOptions(flags)
+	if err != nil {
+		return err
+	}
+	if len(languages) == 2 && (opts.MinLength > 0 || opts.MaxLength > 0) {
+		return usagef("-min-length and -max-length can't be used with two languages")
+	}
+
+	show := func() error {
+		defer warnStorage(storageErr, client)
+
+		if len(languages) == 2 {
+			return printBilingual(ctx, client, languages)
+		}
+
+		phrase, err := client.Random(ctx, opts)
+		if errors.Is(err, motivar.ErrNotFound) {
+			return errors.New("no phrases with this length")
+		}
+		if err != nil {
+			return err
+		}
+
+		printPhrase(phrase)
+		return nil
+	}
+
+	if flags.OncePer > 0 && cfg.StateDir != "" {
+		called, err := claimInterval(filepath.Join(cfg.StateDir, lastShownFile), flags.OncePer, time.Now(), show)
+		if called || err == nil {
+			return err
+		}
+		// Better to show the phrase again than none.
+		logg.Debug(fmt.Sprintf("Checking last phrase shown: %v", err))
+	}
+	return show()
+}
+
+// phraseOptions returns the options of the phrase shown by f, or a usage
+// error if they are invalid.
+func phraseOptions(f Flags) (motivar.Options, error) {
+	if f.MinLength < 0 || f.MaxLength < 0 {
+		return motivar.Options{}, usagef("-min-length and -max-length must not be negative")
+	}
+	if f.MaxLength > 0 && f.MinLength > f.MaxLength {
+		return motivar.Options{}, usagef("-min-length must not be greater than -max-length")
+	}
+	if f.LengthUnit != motivar.LengthCharacters && f.LengthUnit != motivar.LengthWords {
+		return motivar.Options{}, usagef("-length-unit must be %s or %s", motivar.LengthCharacters, motivar.LengthWords)
+	}
+	if f.Truncate && f.MaxLength == 0 {
+		return motivar.Options{}, usagef("-truncate needs -max-length")
+	}
+
+	return motivar.Options{
+		Language:   f.Language,
+		MinLength:  f.MinLength,
+		MaxLength:  f.MaxLength,
+		LengthUnit: f.LengthUnit,
+		Truncate:   f.Truncate,
+	}, nil
+}
+
+var addPhrasesCommand = &command{
+	Name:  "add-phrases",
+	Short: "Import phrases from a URL into the database",
+	Flags: bindFlags(&flagsAdd, func(fs *flag.FlagSet, f *FlagsAdd, language string) {
+		fs.StringVar(&f.Format, "fmt", "csv", "Specify format phrases content ["+strings.Join(motivar.Formats(), ",")+"], or auto to detect it")
+		fs.StringVar(&f.URL, "url", "", "Specify URL to download from, or the path of a local file")
+		fs.StringVar(&f.Language, "language", "", "The language of phrases [br,us], or auto to detect it per phrase")
+		fs.StringVar(&f.AuthorSep, "author-sep", "", "Separator of phrase and author in txt files. Default tries —, –, -- and -")
+		fs.Var(&f.Patterns, "pattern", "Regexp with the groups (?P<phrase>...) and (?P<author>...) matched with the title and description of rss items, in two lines. Can be repeated")
+		fs.StringVar(&f.SHA256, "sha256", "", "Refuse the import if the SHA-256 digest of the content isn't this one")
+		fs.StringVar(&f.Signature, "sig", "", "URL or path of the minisign signature of the content. It must be made by a key in the [keys] section of motivar.ini")
+	}),
+	Run: addPhrases,
+}
+
+func addPhrases(ctx context.Context, client *motivar.Client, args []string) error {
+	if err := checkArgs(args, 0, 0); err != nil {
+		return err
+	}
+	if flagsAdd.Format == "" || flagsAdd.URL == "" || flagsAdd.Language == "" {
+		return usagef("-fmt, -url and -language are required")
+	}
+	if flagsAdd.Language != motivar.LanguageAuto {
+		if err := checkLanguage(flagsAdd.Language); err != nil {
+			return err
+		}
+	}
+	if flagsAdd.Format != motivar.FormatAuto {
+		if err := motivar.CheckFormat(flagsAdd.Format); err != nil {
+			return usageError{msg: err.Error()}
+		}
+	}
+
+	var keys []motivar.PublicKey
+	if flagsAdd.Signature != "" {
+		var err error
+		if keys, err = cfg.TrustedKeys(); err != nil {
+			return err
+		}
+	}
+
+	_, err := client.Import(ctx, motivar.Source{
+		URL:             flagsAdd.URL,
+		Format:          flagsAdd.Format,
+		Language:        flagsAdd.Language,
+		AuthorSeparator: flagsAdd.AuthorSep,
+		Patterns:        flagsAdd.Patterns,
+		SHA256:          flagsAdd.SHA256,
+		SignatureURL:    flagsAdd.Signature,
+		TrustedKeys:     keys,
+	})
+	return err
+}
+
+func initDatabase() (*motivar.Client, error) {
+	opts := []motivar.Option{
+		motivar.WithDBPath(cfg.DB),
+		motivar.WithLogger(logg),
+	}
+
+	// A broken conf file doesn't stop the phrases, only its words are lost.
+	filter, safe, err := cfg.ContentFilter()
+	if err != nil {
+		logg.Warn(fmt.Sprintf("Reading the content filter: %v. Using only the built-in words", err))
+		filter = motivar.ContentFilter{}
+	}
+	if flags.Safe || safe {
+		opts = append(opts, motivar.WithContentFilter(filter))
+	}
+	return motivar.New(opts...)
+}
+
+// warnStorage prints to stderr, once, why the files of motivar couldn't be
+// used. setupErr is the error creating them, if any.
+func warnStorage(setupErr error, client *motivar.Client) {
+	err := client.StorageError()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v. Showing only the embedded phrases\n", Name, err)
+		return
+	}
+	if setupErr != nil {
+		fmt.Fprintf(os.Stderr, "%s: %v\n", Name, setupErr)
+	}
+}
+
+// CheckLanguages check languages supported
+func CheckLanguages(lang string) error {
+	langs := motivar.Languages()
+
+	for _, l := range langs {
+		if lang == l {
+			return nil
+		}
+	}
+
+	quoted := make([]string, len(langs))
+	for i, l := range langs {
+		quoted[i] = "'" + l + "'"
+	}
+	list := quoted[0]
+	if last := len(quoted) - 1; last > 0 {
+		list = strings.Join(quoted[:last], ", ") + " or " + quoted[last]
+	}
+	return errors.New("language not supported. Use " + list)
+}
+
+// Setup creates the folders and the conf file that don't exist yet.
+func (c Conf) Setup() error {
+	// create conf, data and state dirs
+	for _, dir := range []string{c.Dir, c.DataDir, c.StateDir, filepath.Dir(c.DB)} {
+		err := os.MkdirAll(dir, 0764)
+		if err != nil {
+			return err
+		}
+	}
+
+	// check and create conf file
+	_, err := os.Stat(c.File)
+	if !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	// Empty: without language, the language of the system locale is used.
+	f, err := os.Create(c.File)
+	if err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// Language returns the language of the conf file, or "" if not set.
+func (c Conf) Language() string {
+	cfg, err := ini.Load(c.File)
+	if err != nil {
+		return ""
+	}
+
+	lang := cfg.Section("").Key("language").String()
+	if CheckLanguages(lang) != nil {
+		return ""
+	}
+	return lang
+}
+
+// TrustedKeys returns the minisign public keys of the [keys] section of the
+// conf file, one per line as name = key:
+//
+//	[keys]
+//	team = RWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0QTaLn73Y7GFO3
+func (c Conf) TrustedKeys() ([]motivar.PublicKey, error) {
+	cfg, err := ini.Load(c.File)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []motivar.PublicKey
+	for _, k := range cfg.Section("keys").Keys() {
+		key, err := motivar.ParsePublicKey(k.Name(), k.String())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", c.File, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// ContentFilter returns the words of the [filter] section of the conf file,
+// and whether the safe mode is on:
+//
+//	[filter]
+//	safe = true
+//	block = lorem, dolor sit*
+//	allow = cocktail
+//
+// Without the conf file, the filter has only the built-in words.
+func (c Conf) ContentFilter() (filter motivar.ContentFilter, safe bool, err error) {
+	if c.File == "" {
+		return filter, false, nil
+	}
+	cfg, err := ini.Load(c.File)
+	if errors.Is(err, fs.ErrNotExist) {
+		return filter, false, nil
+	}
+	if err != nil {
+		return filter, false, err
+	}
+
+	section := cfg.Section("filter")
+	if section.HasKey("safe") {
+		if safe, err = section.Key("safe").Bool(); err != nil {
+			return filter, false, fmt.Errorf("%s: safe must be true or false", c.File)
+		}
+	}
+	filter.Block = section.Key("block").Strings(",")
+	filter.Allow = section.Key("allow").Strings(",")
+	return filter, safe, nil
+}
+
+func printPhrase(p motivar.Phrase) {
+	fmt.Println(formatPhrase(p))
+}
+
+// formatPhrase renders a phrase as shown in the terminal.
+func formatPhrase(p motivar.Phrase) string {
+	return fmt.Sprintf("%+v %+v", p.Phrase, p.Author)
+}
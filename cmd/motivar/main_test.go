@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/wvoliveira/motivar"
+)
+
+func TestCheckLanguages(t *testing.T) {
+	langError := "language not supported. Use 'br' or 'us'"
+	languages := []struct {
+		lang   string
+		result string
+	}{
+		{"br", ""},
+		{"us", ""},
+		{"fr", langError},
+		{"ko", langError},
+		{"la", langError},
+		{"zh", langError},
+	}
+
+	for _, l := range languages {
+		err := CheckLanguages(l.lang)
+		if err != nil {
+			if err.Error() != l.result {
+				t.Errorf("Language %v was not supported, got: %v, want: %v.", l.lang, err, l.result)
+			}
+		}
+	}
+}
+
+func TestConfSetupError(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c := Conf{
+		Dir:      filepath.Join(file, "config"),
+		File:     filepath.Join(file, "config", "motivar.ini"),
+		DataDir:  filepath.Join(file, "data"),
+		StateDir: filepath.Join(file, "state"),
+		DB:       filepath.Join(file, "data", "database.db"),
+	}
+	if err := c.Setup(); err == nil {
+		t.Error("Setup: want error when a folder can't be created")
+	}
+}
+
+func TestConfSetup(t *testing.T) {
+	dir := t.TempDir()
+	c := Conf{
+		Dir:      filepath.Join(dir, "config"),
+		File:     filepath.Join(dir, "config", "motivar.ini"),
+		DataDir:  filepath.Join(dir, "data"),
+		StateDir: filepath.Join(dir, "state"),
+		DB:       filepath.Join(dir, "data", "database.db"),
+	}
+	if err := c.Setup(); err != nil {
+		t.Fatal(err)
+	}
+	// The language of the system locale is used without language.
+	content, err := os.ReadFile(c.File)
+	if err != nil || len(content) > 0 {
+		t.Errorf("conf file: got %q, %v, want it empty", content, err)
+	}
+}
+
+func TestConfContentFilter(t *testing.T) {
+	c := Conf{File: filepath.Join(t.TempDir(), "motivar.ini")}
+	filter, safe, err := c.ContentFilter()
+	if err != nil || safe || filter.Block != nil {
+		t.Errorf("without conf file: got %+v, %v, %v", filter, safe, err)
+	}
+
+	conf := "language = us\n\n[filter]\nsafe = true\nblock = lorem, dolor sit*\nallow = cocktail\n"
+	if err = os.WriteFile(c.File, []byte(conf), 0644); err != nil {
+		t.Fatal(err)
+	}
+	filter, safe, err = c.ContentFilter()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !safe || !slices.Equal(filter.Block, []string{"lorem", "dolor sit*"}) || !slices.Equal(filter.Allow, []string{"cocktail"}) {
+		t.Errorf("got %+v, safe %v", filter, safe)
+	}
+
+	if err = os.WriteFile(c.File, []byte("[filter]\nsafe = maybe\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = c.ContentFilter(); err == nil {
+		t.Error("safe = maybe: want error")
+	}
+}
+
+func TestInitDatabaseBrokenConf(t *testing.T) {
+	logg = slog.New(slog.DiscardHandler)
+	dir := t.TempDir()
+	cfg = Conf{File: filepath.Join(dir, "motivar.ini"), DB: filepath.Join(dir, "database.db")}
+	t.Cleanup(func() { cfg = Conf{} })
+
+	if err := os.WriteFile(cfg.File, []byte("[filter\nsafe = maybe\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	client, err := initDatabase()
+	if err != nil {
+		t.Fatalf("initDatabase: want the built-in filter for a broken conf file, got %v", err)
+	}
+	client.Close()
+}
+
+func TestPrintBilingualError(t *testing.T) {
+	client, err := motivar.New(motivar.WithDBPath(filepath.Join(t.TempDir(), "database.db")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// Returned to exit with an error code, not only printed.
+	if err = printBilingual(context.Background(), client, []string{"br", "xx"}); err == nil {
+		t.Error("printBilingual: want error for an unsupported language")
+	}
+}
+
+func TestPhraseOptions(t *testing.T) {
+	valid := Flags{Language: "us", MaxLength: 40, LengthUnit: "words", Truncate: true}
+	opts, err := phraseOptions(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Language != "us" || opts.MaxLength != 40 || opts.LengthUnit != "words" || !opts.Truncate {
+		t.Errorf("got %+v", opts)
+	}
+
+	invalid := []Flags{
+		{MinLength: -1, LengthUnit: "chars"},
+		{MinLength: 50, MaxLength: 40, LengthUnit: "chars"},
+		{MaxLength: 40, LengthUnit: "lines"},
+		{Truncate: true, LengthUnit: "chars"},
+	}
+	for _, f := range invalid {
+		var usageErr usageError
+		if _, err := phraseOptions(f); !errors.As(err, &usageErr) {
+			t.Errorf("phraseOptions(%+v): got %v, want a usage error", f, err)
+		}
+	}
+}
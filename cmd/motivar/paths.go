@@ -0,0 +1,69 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/wvoliveira/motivar"
+	"gopkg.in/ini.v1"
+)
+
+// resolveConf sets the paths of cfg, moving the files of ~/.motivar to the
+// XDG folders when they don't exist yet. The paths are set even if moving
+// fails.
+func resolveConf(home, db string) error {
+	paths, err := motivar.ResolvePaths(home, db)
+	if err != nil {
+		return err
+	}
+
+	cfg = Conf{
+		Dir:      paths.ConfigDir,
+		File:     paths.ConfigFile,
+		DataDir:  paths.DataDir,
+		StateDir: paths.StateDir,
+		DB:       paths.DB,
+	}
+
+	legacy, err := motivar.LegacyHome()
+	if err != nil {
+		return err
+	}
+	// Only a conf file written by older versions may have the default language.
+	_, err = os.Stat(cfg.File)
+	newConf := errors.Is(err, fs.ErrNotExist)
+
+	moved, err := motivar.MigrateLegacyHome(legacy, paths)
+	if err != nil {
+		return fmt.Errorf("moving %s: %w", legacy, err)
+	}
+	if moved {
+		logg.Info(fmt.Sprintf("Moved files of %s to %s and %s", legacy, paths.ConfigDir, paths.DataDir))
+	}
+	if moved && newConf {
+		return cfg.dropDefaultLanguage()
+	}
+	return nil
+}
+
+// dropDefaultLanguage removes language = br from the conf file when it's the
+// only key, as older versions wrote it to every conf file. Otherwise it would
+// always win over the system locale.
+func (c Conf) dropDefaultLanguage() error {
+	file, err := ini.Load(c.File)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	root := file.Section("")
+	if len(file.Sections()) > 1 || len(root.Keys()) != 1 || root.Key("language").String() != "br" {
+		return nil
+	}
+	root.DeleteKey("language")
+	return file.SaveTo(c.File)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestSplitGlobalFlags(t *testing.T) {
+	var f Flags
+	rest, err := splitGlobalFlags([]string{"add-phrases", "-home", "/h", "-fmt", "csv", "--db=/tmp/x.db", "-debug", "-language", "br"}, &f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []string{"add-phrases", "-fmt", "csv", "-language", "br"}; !slices.Equal(rest, want) {
+		t.Errorf("rest: got %v, want %v", rest, want)
+	}
+	if f.Home != "/h" || f.DB != "/tmp/x.db" || !f.Debug {
+		t.Errorf("flags: got home %q, db %q and debug %v", f.Home, f.DB, f.Debug)
+	}
+
+	if _, err = splitGlobalFlags([]string{"-db"}, &f); err == nil {
+		t.Error("expected error for flag without value")
+	}
+	if _, err = splitGlobalFlags([]string{"-debug=maybe"}, &f); err == nil {
+		t.Error("expected error for invalid bool value")
+	}
+}
+
+func TestDropDefaultLanguage(t *testing.T) {
+	confs := []struct {
+		conf string
+		lang string
+	}{
+		// Written by older versions to every conf file.
+		{"language = br\n", ""},
+		{"language = us\n", "us"},
+		{"language = br\n\n[filter]\nsafe = true\n", "br"},
+	}
+
+	for _, tt := range confs {
+		c := Conf{File: filepath.Join(t.TempDir(), "motivar.ini")}
+		if err := os.WriteFile(c.File, []byte(tt.conf), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.dropDefaultLanguage(); err != nil {
+			t.Fatal(err)
+		}
+		if got := c.Language(); got != tt.lang {
+			t.Errorf("%q: got language %q, want %q", tt.conf, got, tt.lang)
+		}
+	}
+
+	if err := (Conf{File: filepath.Join(t.TempDir(), "none.ini")}).dropDefaultLanguage(); err != nil {
+		t.Errorf("without conf file: %v", err)
+	}
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/wvoliveira/motivar"
+)
+
+// Quote of the Day protocol (RFC 865). Each TCP connection or UDP datagram
+// received is answered with one phrase. The RFC limits the message to 512
+// characters.
+
+const (
+	qotdMaxLength    = 512
+	qotdWriteTimeout = 5 * time.Second
+	// qotdAttempts is how many phrases are tried before truncating one that
+	// doesn't fit the message limit.
+	qotdAttempts = 5
+)
+
+type FlagsQOTD struct {
+	Host     string
+	Port     int
+	Language string
+	MaxConns int
+}
+
+var serveQOTDCommand = &command{
+	Name:  "serve-qotd",
+	Short: "Serve phrases with the Quote of the Day protocol (RFC 865)",
+	Flags: bindFlags(&flagsQOTD, func(fs *flag.FlagSet, f *FlagsQOTD, language string) {
+		fs.StringVar(&f.Host, "host", "", "Host to listen on")
+		fs.IntVar(&f.Port, "port", 17, "TCP and UDP port to listen on")
+		fs.StringVar(&f.Language, "language", language, "Language of phrases [br,us]")
+		fs.IntVar(&f.MaxConns, "max-conns", 64, "Max connections handled at same time")
+	}),
+	Run: func(ctx context.Context, client *motivar.Client, args []string) error {
+		if err := checkArgs(args, 0, 0); err != nil {
+			return err
+		}
+		if err := checkLanguage(flagsQOTD.Language); err != nil {
+			return err
+		}
+
+		warnStorage(storageErr, client)
+		return serveQOTD(client, flagsQOTD)
+	},
+}
+
+type qotdServer struct {
+	client   *motivar.Client
+	language string
+	// conns limits the TCP connections and UDP answers handled at same time.
+	conns chan struct{}
+}
+
+func newQOTDServer(client *motivar.Client, language string, maxConns int) *qotdServer {
+	return &qotdServer{
+		client:   client,
+		language: language,
+		conns:    make(chan struct{}, maxConns),
+	}
+}
+
+// serveQOTD listens on TCP and UDP until SIGINT or SIGTERM.
+func serveQOTD(client *motivar.Client, f FlagsQOTD) error {
+	if f.MaxConns < 1 {
+		return errors.New("max connections must be at least 1")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	addr := net.JoinHostPort(f.Host, strconv.Itoa(f.Port))
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	packetConn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		listener.Close()
+		return err
+	}
+
+	s := newQOTDServer(client, f.Language, f.MaxConns)
+	logg.Info(fmt.Sprintf("Listening QOTD on %s (tcp and udp)", addr))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		errs <- s.serveTCP(ctx, listener)
+	}()
+	go func() {
+		defer wg.Done()
+		errs <- s.serveUDP(ctx, packetConn)
+	}()
+
+	select {
+	case err = <-errs:
+	case <-ctx.Done():
+		logg.Info("Shutting down...")
+	}
+
+	listener.Close()
+	packetConn.Close()
+	wg.Wait()
+
+	if errors.Is(err, net.ErrClosed) {
+		return nil
+	}
+	return err
+}
+
+func (s *qotdServer) serveTCP(ctx context.Context, listener net.Listener) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+
+		select {
+		case s.conns <- struct{}{}:
+		default:
+			logg.Warn(fmt.Sprintf("Too many connections, refusing %s", conn.RemoteAddr()))
+			conn.Close()
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-s.conns }()
+			defer conn.Close()
+
+			_ = conn.SetWriteDeadline(time.Now().Add(qotdWriteTimeout))
+			if _, err := conn.Write(s.message(ctx)); err != nil {
+				logg.Debug(fmt.Sprintf("Writing to %s: %v", conn.RemoteAddr(), err))
+			}
+		}()
+	}
+}
+
+func (s *qotdServer) serveUDP(ctx context.Context, conn net.PacketConn) error {
+	buf := make([]byte, qotdMaxLength)
+	for {
+		// The content of the datagram is ignored.
+		_, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case s.conns <- struct{}{}:
+		default:
+			logg.Warn(fmt.Sprintf("Too many requests, ignoring %s", addr))
+			continue
+		}
+
+		_ = conn.SetWriteDeadline(time.Now().Add(qotdWriteTimeout))
+		if _, err = conn.WriteTo(s.message(ctx), addr); err != nil {
+			logg.Debug(fmt.Sprintf("Writing to %s: %v", addr, err))
+		}
+		<-s.conns
+	}
+}
+
+// message returns a phrase formatted as printPhrase does, ending with CRLF
+// and fitting the limit of the RFC.
+func (s *qotdServer) message(ctx context.Context) []byte {
+	var text string
+	for range qotdAttempts {
+		phrase, err := s.client.Random(ctx, motivar.Options{Language: s.language})
+		if err != nil {
+			logg.Error(err.Error())
+			continue
+		}
+
+		text = formatPhrase(phrase)
+		if len(text)+2 <= qotdMaxLength {
+			break
+		}
+	}
+	return []byte(truncateBytes(text, qotdMaxLength-2) + "\r\n")
+}
+
+// truncateBytes cuts s to at most n bytes without breaking a UTF-8 character.
+func truncateBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"log/slog"
+	"net"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/wvoliveira/motivar"
+)
+
+func TestTruncateBytes(t *testing.T) {
+	tests := []struct {
+		s    string
+		n    int
+		want string
+	}{
+		{"short", 10, "short"},
+		{"exactly", 7, "exactly"},
+		{"motivação", 7, "motiva"},
+		{"motivação", 9, "motivaç"},
+	}
+
+	for _, tt := range tests {
+		if got := truncateBytes(tt.s, tt.n); got != tt.want {
+			t.Errorf("truncateBytes(%q, %d): got %q, want %q", tt.s, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestQOTD(t *testing.T) {
+	logg = slog.New(slog.DiscardHandler)
+
+	client, err := motivar.New(motivar.WithDBPath(filepath.Join(t.TempDir(), "database.db")))
+	if err != nil {
+		t.Fatalf("motivar.New: %v", err)
+	}
+	defer client.Close()
+
+	s := newQOTDServer(client, "us", 1)
+	ctx := context.Background()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go s.serveTCP(ctx, listener)
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		t.Fatalf("reading tcp: %v", err)
+	}
+	if !strings.HasSuffix(line, "\r\n") || len(line) > qotdMaxLength {
+		t.Errorf("tcp message: got %q", line)
+	}
+
+	packetConn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer packetConn.Close()
+	go s.serveUDP(ctx, packetConn)
+
+	udp, err := net.Dial("udp", packetConn.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer udp.Close()
+	_ = udp.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if _, err = udp.Write([]byte("\n")); err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, 1024)
+	n, err := udp.Read(buf)
+	if err != nil {
+		t.Fatalf("reading udp: %v", err)
+	}
+	if n == 0 || n > qotdMaxLength {
+		t.Errorf("udp message: got %d bytes", n)
+	}
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/wvoliveira/motivar"
+)
+
+//go:embed openapi.json
+var openAPI []byte
+
+const (
+	contentJSON = "application/json"
+	contentText = "text/plain"
+
+	shutdownTimeout = 10 * time.Second
+	maxPageLimit    = 100
+
+	// Timeouts of the connections, so slow or idle clients don't hold them
+	// forever.
+	readHeaderTimeout = 5 * time.Second
+	readTimeout       = 10 * time.Second
+	writeTimeout      = 10 * time.Second
+	idleTimeout       = 60 * time.Second
+)
+
+type FlagsServe struct {
+	Addr     string
+	Language string
+}
+
+var serveCommand = &command{
+	Name:  "serve",
+	Short: "Serve the quotes REST API over HTTP",
+	Flags: bindFlags(&flagsServe, func(fs *flag.FlagSet, f *FlagsServe, language string) {
+		fs.StringVar(&f.Addr, "addr", ":8080", "Address to listen on")
+		fs.StringVar(&f.Language, "language", language, "Default language of phrases [br,us]")
+	}),
+	Run: func(ctx context.Context, client *motivar.Client, args []string) error {
+		if err := checkArgs(args, 0, 0); err != nil {
+			return err
+		}
+		if err := checkLanguage(flagsServe.Language); err != nil {
+			return err
+		}
+
+		warnStorage(storageErr, client)
+		return serve(client, flagsServe.Addr, flagsServe.Language)
+	},
+}
+
+// serve runs the HTTP API until SIGINT or SIGTERM, then waits the pending
+// requests to finish.
+func serve(client *motivar.Client, addr, language string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           newServer(client, language),
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		WriteTimeout:      writeTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		logg.Info(fmt.Sprintf("Listening on %s", addr))
+		errs <- srv.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	logg.Info("Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return srv.Shutdown(shutdownCtx)
+}
+
+type server struct {
+	client   *motivar.Client
+	language string
+}
+
+func newServer(client *motivar.Client, language string) http.Handler {
+	s := server{client: client, language: language}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/random", s.random)
+	mux.HandleFunc("GET /v1/today", s.today)
+	mux.HandleFunc("GET /v1/phrases", s.phrases)
+	mux.HandleFunc("GET /v1/phrases/{id}", s.phrase)
+	mux.HandleFunc("GET /v1/search", s.search)
+	mux.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentJSON)
+		_, _ = w.Write(openAPI)
+	})
+	return mux
+}
+
+type pageResponse struct {
+	Phrases []motivar.Phrase `json:"phrases"`
+	Total   int              `json:"total"`
+	Offset  int              `json:"offset"`
+	Limit   int              `json:"limit"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (s server) random(w http.ResponseWriter, r *http.Request) {
+	opts, err := s.options(r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	phrase, err := s.client.Random(r.Context(), opts)
+	if err != nil {
+		writeClientError(w, r, err)
+		return
+	}
+	writePhrases(w, r, phrase, phrase)
+}
+
+func (s server) today(w http.ResponseWriter, r *http.Request) {
+	opts, err := s.options(r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	phrase, err := s.client.Today(r.Context(), opts)
+	if err != nil {
+		writeClientError(w, r, err)
+		return
+	}
+	writePhrases(w, r, phrase, phrase)
+}
+
+func (s server) phrases(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	opts, err := s.options(r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err)
+		return
+	}
+	offset, err := queryInt(query.Get("offset"), 0)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, fmt.Errorf("offset: %w", err))
+		return
+	}
+	limit, err := queryInt(query.Get("limit"), 20)
+	if err != nil || limit < 1 || limit > maxPageLimit {
+		writeError(w, r, http.StatusBadRequest, fmt.Errorf("limit must be between 1 and %d", maxPageLimit))
+		return
+	}
+
+	phrases, total, err := s.client.List(r.Context(), motivar.ListOptions{
+		Options: opts,
+		Author:  query.Get("author"),
+		Offset:  offset,
+		Limit:   limit,
+	})
+	if err != nil {
+		writeClientError(w, r, err)
+		return
+	}
+
+	writePhrases(w, r, pageResponse{
+		Phrases: nonNil(phrases),
+		Total:   total,
+		Offset:  offset,
+		Limit:   limit,
+	}, phrases...)
+}
+
+func (s server) phrase(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, errors.New("invalid phrase id"))
+		return
+	}
+
+	phrase, err := s.client.Get(r.Context(), id)
+	if err != nil {
+		writeClientError(w, r, err)
+		return
+	}
+	writePhrases(w, r, phrase, phrase)
+}
+
+func (s server) search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	q := strings.TrimSpace(query.Get("q"))
+	if q == "" {
+		writeError(w, r, http.StatusBadRequest, errors.New("missing query parameter q"))
+		return
+	}
+
+	opts, err := s.options(r)
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	phrases, err := s.client.Search(r.Context(), q, opts)
+	if err != nil {
+		writeClientError(w, r, err)
+		return
+	}
+	writePhrases(w, r, nonNil(phrases), phrases...)
+}
+
+// options returns the language of the query or the server language, or an
+// error if it's not supported.
+func (s server) options(r *http.Request) (motivar.Options, error) {
+	language := r.URL.Query().Get("language")
+	if language == "" {
+		language = s.language
+	}
+	if err := CheckLanguages(language); err != nil {
+		return motivar.Options{}, err
+	}
+	return motivar.Options{Language: language}, nil
+}
+
+// writePhrases writes v as JSON or phrases as plain text, one per line,
+// according to the Accept header.
+func writePhrases(w http.ResponseWriter, r *http.Request, v any, phrases ...motivar.Phrase) {
+	switch negotiate(r) {
+	case contentText:
+		w.Header().Set("Content-Type", contentText+"; charset=utf-8")
+		for _, p := range phrases {
+			_, _ = fmt.Fprintln(w, formatPhrase(p))
+		}
+	case contentJSON:
+		w.Header().Set("Content-Type", contentJSON)
+		_ = json.NewEncoder(w).Encode(v)
+	default:
+		writeError(w, r, http.StatusNotAcceptable, errors.New("supported content types: application/json, text/plain"))
+	}
+}
+
+// writeClientError writes err of the client: not found, or an error of the
+// database.
+func writeClientError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, motivar.ErrNotFound) {
+		writeError(w, r, http.StatusNotFound, err)
+		return
+	}
+	writeError(w, r, http.StatusInternalServerError, err)
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, status int, err error) {
+	if negotiate(r) == contentText {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentJSON)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorResponse{Error: err.Error()})
+}
+
+// negotiate returns the preferred content type of the Accept header, JSON
+// when there is no preference or "" when none is acceptable.
+func negotiate(r *http.Request) string {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return contentJSON
+	}
+
+	best, bestQ := "", 0.0
+	for _, item := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(item))
+		if err != nil {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			q, err = strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+		}
+
+		var candidate string
+		switch mediaType {
+		case contentJSON, "application/*", "*/*":
+			candidate = contentJSON
+		case contentText, "text/*":
+			candidate = contentText
+		default:
+			continue
+		}
+
+		if q > bestQ {
+			best, bestQ = candidate, q
+		}
+	}
+	return best
+}
+
+func queryInt(value string, fallback int) (int, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err == nil && n < 0 {
+		err = errors.New("must not be negative")
+	}
+	return n, err
+}
+
+func nonNil(phrases []motivar.Phrase) []motivar.Phrase {
+	if phrases == nil {
+		return []motivar.Phrase{}
+	}
+	return phrases
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/wvoliveira/motivar"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	client, err := motivar.New(motivar.WithDBPath(filepath.Join(t.TempDir(), "database.db")))
+	if err != nil {
+		t.Fatalf("motivar.New: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	server := httptest.NewServer(newServer(client, "us"))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(t *testing.T, url, accept string) *http.Response {
+	t.Helper()
+
+	req, _ := http.NewRequest(http.MethodGet, url, nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestServePhrases(t *testing.T) {
+	server := newTestServer(t)
+
+	resp := get(t, server.URL+"/v1/phrases?limit=5&offset=2", "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var page pageResponse
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		t.Fatalf("decoding page: %v", err)
+	}
+	if len(page.Phrases) != 5 || page.Offset != 2 || page.Total < 7 {
+		t.Fatalf("page: got %d phrases, offset %d, total %d", len(page.Phrases), page.Offset, page.Total)
+	}
+	if page.Phrases[0].Language != "us" {
+		t.Errorf("language: got %q, want server default %q", page.Phrases[0].Language, "us")
+	}
+
+	resp = get(t, fmt.Sprintf("%s/v1/phrases/%d", server.URL, page.Phrases[0].ID), "")
+	var phrase motivar.Phrase
+	if err := json.NewDecoder(resp.Body).Decode(&phrase); err != nil {
+		t.Fatalf("decoding phrase: %v", err)
+	}
+	if phrase != page.Phrases[0] {
+		t.Errorf("phrase by id: got %+v, want %+v", phrase, page.Phrases[0])
+	}
+
+	if resp = get(t, server.URL+"/v1/phrases/1", ""); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown id: got %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+	if resp = get(t, server.URL+"/v1/phrases?limit=1000", ""); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("big limit: got %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+	for _, path := range []string{"/v1/random", "/v1/today", "/v1/phrases", "/v1/search?q=a"} {
+		sep := "?"
+		if strings.Contains(path, "?") {
+			sep = "&"
+		}
+		if resp = get(t, server.URL+path+sep+"language=xx", ""); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s with unsupported language: got %d, want %d", path, resp.StatusCode, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestServeNegotiation(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := []struct {
+		accept      string
+		status      int
+		contentType string
+	}{
+		{"", http.StatusOK, contentJSON},
+		{"application/json", http.StatusOK, contentJSON},
+		{"text/plain", http.StatusOK, contentText},
+		{"text/plain;q=0.5, application/json", http.StatusOK, contentJSON},
+		{"application/json;q=0.2, text/*", http.StatusOK, contentText},
+		{"image/png", http.StatusNotAcceptable, contentJSON},
+	}
+
+	for _, tt := range tests {
+		resp := get(t, server.URL+"/v1/today?language=br", tt.accept)
+		if resp.StatusCode != tt.status {
+			t.Errorf("Accept %q: status got %d, want %d", tt.accept, resp.StatusCode, tt.status)
+		}
+		if got := resp.Header.Get("Content-Type"); !strings.HasPrefix(got, tt.contentType) {
+			t.Errorf("Accept %q: content type got %q, want %q", tt.accept, got, tt.contentType)
+		}
+	}
+}
+
+func TestServeSearch(t *testing.T) {
+	server := newTestServer(t)
+
+	resp := get(t, server.URL+"/v1/search?q=lincoln", "text/plain")
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status: got %d", resp.StatusCode)
+	}
+	if !strings.Contains(string(body), "Abraham Lincoln") {
+		t.Errorf("search: got %q, want phrases of Abraham Lincoln", body)
+	}
+
+	if resp = get(t, server.URL+"/v1/search", ""); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("missing q: got %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	resp = get(t, server.URL+"/openapi.json", "")
+	var doc map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil || doc["openapi"] == nil {
+		t.Errorf("openapi.json: invalid document (%v)", err)
+	}
+}
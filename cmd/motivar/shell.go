@@ -0,0 +1,487 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/wvoliveira/motivar"
+)
+
+// Shells with integration snippets and completions.
+var shells = []string{"bash", "zsh", "fish", "powershell"}
+
+// lastShownFile in the state folder keeps when a phrase was shown by -once-per.
+const lastShownFile = "last-shown"
+
+// lockTimeout is how old the lock of the state file must be to be taken as
+// left behind by a killed process.
+const lockTimeout = 10 * time.Second
+
+type FlagsInit struct {
+	OncePer time.Duration
+}
+
+var initCommand = &command{
+	Name:    "init",
+	Args:    "<bash|zsh|fish|powershell>",
+	Short:   "Print the snippet that shows a phrase when the shell starts",
+	Actions: shells,
+	Flags:   bindFlags(&flagsInit, initFlags),
+	// The flags can come after the shell too.
+	Interspersed: true,
+	Run: func(ctx context.Context, client *motivar.Client, args []string) error {
+		if err := checkArgs(args[1:], 0, 0); err != nil {
+			return err
+		}
+		return shellInit(os.Stdout, args[0], flagsInit, newCompletionSpec())
+	},
+}
+
+func initFlags(fs *flag.FlagSet, f *FlagsInit, language string) {
+	fs.DurationVar(&f.OncePer, "once-per", 0, "Show a phrase at most once in this interval, like 4h. Default is every shell")
+}
+
+var completionCommand = &command{
+	Name:    "completion",
+	Args:    "<bash|zsh|fish|powershell>",
+	Short:   "Print the completion script of the shell",
+	Actions: shells,
+	Run: func(ctx context.Context, client *motivar.Client, args []string) error {
+		if err := checkArgs(args, 1, 1); err != nil {
+			return err
+		}
+		return completion(os.Stdout, args[0], newCompletionSpec())
+	},
+}
+
+// shellInit writes the snippet that shows a phrase when shell starts, with
+// the completions of motivar.
+func shellInit(w io.Writer, shell string, f FlagsInit, spec completionSpec) error {
+	if !slices.Contains(shells, shell) {
+		return fmt.Errorf("shell %q not supported. Use %s", shell, strings.Join(shells, ", "))
+	}
+
+	command := Name
+	if f.OncePer > 0 {
+		command += " -once-per " + formatDuration(f.OncePer)
+	}
+
+	err := completion(w, shell, spec)
+	if err != nil {
+		return err
+	}
+	return initTemplate.ExecuteTemplate(w, shell, command)
+}
+
+// formatDuration formats d without the zero units of time.Duration.String,
+// like 4h instead of 4h0m0s.
+func formatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// completedCommand is a subcommand with the words that can follow it.
+type completedCommand struct {
+	Name    string
+	Actions []string
+	Flags   []completionFlag
+}
+
+type completionFlag struct {
+	Name  string
+	Usage string
+	// Values the flag accepts, if known.
+	Values []string
+	// IsBool flags don't take a value.
+	IsBool bool
+	// IsFile flags take a file path.
+	IsFile bool
+}
+
+// completionSpec is what the completion scripts complete: the flags of the
+// main command and the subcommands.
+type completionSpec struct {
+	Flags    []completionFlag
+	Commands []completedCommand
+}
+
+// ValueFlags returns the names of all flags that take a value.
+func (s completionSpec) ValueFlags() []string {
+	var names []string
+	for _, flags := range append([][]completionFlag{s.Flags}, s.commandFlags()...) {
+		for _, f := range flags {
+			if !f.IsBool && !slices.Contains(names, f.Name) {
+				names = append(names, f.Name)
+			}
+		}
+	}
+	return names
+}
+
+// ValuesOf returns the known values of the flags by name, of all commands.
+func (s completionSpec) ValuesOf() map[string][]string {
+	values := map[string][]string{}
+	for _, flags := range append([][]completionFlag{s.Flags}, s.commandFlags()...) {
+		for _, f := range flags {
+			for _, v := range f.Values {
+				if !slices.Contains(values[f.Name], v) {
+					values[f.Name] = append(values[f.Name], v)
+				}
+			}
+		}
+	}
+	return values
+}
+
+// FileFlags returns the names of the flags that take a file path.
+func (s completionSpec) FileFlags() []string {
+	var names []string
+	for _, f := range s.Flags {
+		if f.IsFile {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+// TopLevel returns the words completed right after motivar.
+func (s completionSpec) TopLevel() []string {
+	var words []string
+	for _, c := range s.Commands {
+		words = append(words, c.Name)
+	}
+	for _, f := range s.Flags {
+		words = append(words, "-"+f.Name)
+	}
+	return words
+}
+
+func (s completionSpec) commandFlags() [][]completionFlag {
+	var flags [][]completionFlag
+	for _, c := range s.Commands {
+		flags = append(flags, c.Flags)
+	}
+	return flags
+}
+
+// Words returns the actions and the flags of c.
+func (c completedCommand) Words() []string {
+	words := slices.Clone(c.Actions)
+	for _, f := range c.Flags {
+		words = append(words, "-"+f.Name)
+	}
+	return words
+}
+
+// flagsOf returns the flags of c to complete.
+func flagsOf(c *command) []completionFlag {
+	fs, _ := c.flagSet("")
+
+	var flags []completionFlag
+	fs.VisitAll(func(f *flag.Flag) {
+		cf := completionFlag{Name: f.Name, Usage: f.Usage}
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+			cf.IsBool = true
+		}
+		switch f.Name {
+		case "l", "language":
+			cf.Values = motivar.Languages()
+			if c == addPhrasesCommand || c == importCommand {
+				cf.Values = append(cf.Values, motivar.LanguageAuto)
+			}
+		case "fmt":
+			cf.Values = append(motivar.Formats(), motivar.FormatAuto)
+			if c == exportCommand {
+				cf.Values = motivar.ExportFormats()
+			}
+		case "length-unit":
+			cf.Values = []string{motivar.LengthCharacters, motivar.LengthWords}
+		}
+		flags = append(flags, cf)
+	})
+	return flags
+}
+
+// newCompletionSpec returns the spec of the commands of motivar.
+func newCompletionSpec() completionSpec {
+	spec := completionSpec{Flags: flagsOf(rootCommand)}
+
+	// Global flags are accepted by every command, but only completed first.
+	globalFlagSet(&Flags{}).VisitAll(func(f *flag.Flag) {
+		_, isBool := f.Value.(interface{ IsBoolFlag() bool })
+		cf := completionFlag{
+			Name:   f.Name,
+			Usage:  f.Usage,
+			IsBool: isBool,
+			IsFile: f.Name == "home" || f.Name == "db",
+		}
+		switch f.Name {
+		case "log-level":
+			cf.Values = []string{"debug", "info", "warn", "error"}
+		case "log-format":
+			cf.Values = logFormats
+		}
+		spec.Flags = append(spec.Flags, cf)
+	})
+
+	for _, c := range commands {
+		spec.Commands = append(spec.Commands, completedCommand{
+			Name:    c.Name,
+			Actions: c.Actions,
+			Flags:   flagsOf(c),
+		})
+	}
+	return spec
+}
+
+// completion writes the completion script of shell.
+func completion(w io.Writer, shell string, spec completionSpec) error {
+	if !slices.Contains(shells, shell) {
+		return fmt.Errorf("shell %q not supported. Use %s", shell, strings.Join(shells, ", "))
+	}
+	return completionTemplate.ExecuteTemplate(w, shell, spec)
+}
+
+// claimInterval calls show when a phrase can be shown at now, when phrases
+// are shown at most once per interval, and saves now in file if show
+// succeeds. When many shells start at same time only one of them shows it.
+// It reports whether show was called, and then returns its error. Otherwise
+// the error is of the files.
+func claimInterval(file string, interval time.Duration, now time.Time, show func() error) (bool, error) {
+	lock := file + ".lock"
+	f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if errors.Is(err, fs.ErrExist) {
+		info, statErr := os.Stat(lock)
+		if statErr != nil || now.Sub(info.ModTime()) < lockTimeout {
+			// Another shell is deciding, or has just shown a phrase.
+			return false, nil
+		}
+
+		err = os.Remove(lock)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return false, err
+		}
+		f, err = os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if errors.Is(err, fs.ErrExist) {
+			return false, nil
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+	f.Close()
+	defer os.Remove(lock)
+
+	content, err := os.ReadFile(file)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+	if err == nil {
+		last, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(string(content)))
+		// A time in the future means the clock changed: show it again.
+		if elapsed := now.Sub(last); err == nil && elapsed >= 0 && elapsed < interval {
+			return false, nil
+		}
+	}
+
+	// Saved only once shown, holding the lock, so a failure to show doesn't
+	// silence the next shells.
+	if err = show(); err != nil {
+		return true, err
+	}
+	err = os.WriteFile(file, []byte(now.Format(time.RFC3339Nano)+"\n"), 0644)
+	if err != nil {
+		logg.Debug(fmt.Sprintf("Saving last phrase shown: %v", err))
+	}
+	return true, nil
+}
+
+var shellFuncs = template.FuncMap{
+	"join": strings.Join,
+	"fishQuote": func(s string) string {
+		return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+	},
+	"psQuote": func(s string) string {
+		return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+	},
+	"psList": func(words []string) string {
+		quoted := make([]string, len(words))
+		for i, w := range words {
+			quoted[i] = "'" + strings.ReplaceAll(w, "'", "''") + "'"
+		}
+		return "@(" + strings.Join(quoted, ", ") + ")"
+	},
+	"dashed": func(names []string) []string {
+		dashed := make([]string, len(names))
+		for i, n := range names {
+			dashed[i] = "-" + n
+		}
+		return dashed
+	},
+}
+
+var initTemplate = template.Must(template.New("init").Parse(`
+{{- define "bash" }}
+# Show a phrase in interactive shells. Add to ~/.bashrc:
+#   eval "$(motivar init bash)"
+if [[ $- == *i* ]]; then
+	{{ . }}
+fi
+{{ end }}
+
+{{- define "zsh" }}
+# Show a phrase in interactive shells. Add to ~/.zshrc:
+#   eval "$(motivar init zsh)"
+if [[ -o interactive ]]; then
+	{{ . }}
+fi
+{{ end }}
+
+{{- define "fish" }}
+# Show a phrase in interactive shells. Add to ~/.config/fish/config.fish:
+#   motivar init fish | source
+if status is-interactive
+	{{ . }}
+end
+{{ end }}
+
+{{- define "powershell" }}
+# Show a phrase. Add to $PROFILE:
+#   Invoke-Expression (& motivar init powershell | Out-String)
+{{ . }}
+{{ end }}
+`))
+
+var completionTemplate = template.Must(template.New("completion").Funcs(shellFuncs).Parse(`
+{{- define "bash" -}}
+# bash completion for motivar. Load it with:
+#   eval "$(motivar completion bash)"
+_motivar() {
+	local cur=${COMP_WORDS[COMP_CWORD]} prev=${COMP_WORDS[COMP_CWORD-1]}
+	local value_flags=" {{ join (dashed .ValueFlags) " " }} "
+	local cmd="" i
+	for ((i = 1; i < COMP_CWORD; i++)); do
+		case ${COMP_WORDS[i]} in
+		-*=*) ;;
+		-*) [[ $value_flags == *" ${COMP_WORDS[i]} "* ]] && ((i++)) ;;
+		*)
+			cmd=${COMP_WORDS[i]}
+			break
+			;;
+		esac
+	done
+
+	case $prev in
+{{- range $name, $values := .ValuesOf }}
+	-{{ $name }}) COMPREPLY=($(compgen -W "{{ join $values " " }}" -- "$cur")); return ;;
+{{- end }}
+{{- range .FileFlags }}
+	-{{ . }}) COMPREPLY=($(compgen -f -- "$cur")); return ;;
+{{- end }}
+	esac
+
+	local words
+	case $cmd in
+{{- range .Commands }}
+	{{ .Name }}) words="{{ join .Words " " }}" ;;
+{{- end }}
+	*) words="{{ join .TopLevel " " }}" ;;
+	esac
+	COMPREPLY=($(compgen -W "$words" -- "$cur"))
+}
+complete -F _motivar motivar
+{{ end }}
+
+{{- define "zsh" -}}
+# zsh completion for motivar, using the bash completion. Load it with:
+#   eval "$(motivar completion zsh)"
+(( $+functions[compdef] )) || { autoload -U +X compinit && compinit }
+autoload -U +X bashcompinit && bashcompinit
+{{ template "bash" . }}
+{{- end }}
+
+{{- define "fish" -}}
+# fish completion for motivar. Load it with:
+#   motivar completion fish | source
+complete -c motivar -f
+complete -c motivar -n __fish_use_subcommand -a {{ fishQuote (join (.TopLevel) " ") }}
+{{- range .Flags }}
+complete -c motivar -n __fish_use_subcommand {{ template "fishFlag" . }}
+{{- end }}
+{{- range $c := .Commands }}
+{{- if .Actions }}
+complete -c motivar -n '__fish_seen_subcommand_from {{ .Name }}' -a {{ fishQuote (join .Actions " ") }}
+{{- end }}
+{{- range .Flags }}
+complete -c motivar -n '__fish_seen_subcommand_from {{ $c.Name }}' {{ template "fishFlag" . }}
+{{- end }}
+{{- end }}
+{{ end }}
+
+{{- define "fishFlag" -}}
+-o {{ .Name }}
+{{- if .Values }} -x -a {{ fishQuote (join .Values " ") }}
+{{- else if .IsFile }} -r -F
+{{- else if not .IsBool }} -x
+{{- end }} -d {{ fishQuote .Usage }}
+{{- end }}
+
+{{- define "powershell" -}}
+# PowerShell completion for motivar. Load it with:
+#   motivar completion powershell | Out-String | Invoke-Expression
+Register-ArgumentCompleter -Native -CommandName motivar, motivar.exe -ScriptBlock {
+	param($wordToComplete, $commandAst, $cursorPosition)
+
+	$commands = @{
+{{- range .Commands }}
+		{{ psQuote .Name }} = {{ psList .Words }}
+{{- end }}
+	}
+	$values = @{
+{{- range $name, $values := .ValuesOf }}
+		{{ psQuote (print "-" $name) }} = {{ psList $values }}
+{{- end }}
+	}
+	$valueFlags = {{ psList (dashed .ValueFlags) }}
+
+	$words = @($commandAst.CommandElements | Select-Object -Skip 1 | ForEach-Object { $_.ToString() })
+	if ($wordToComplete) {
+		$words = @($words | Select-Object -SkipLast 1)
+	}
+
+	$candidates = {{ psList .TopLevel }}
+	for ($i = 0; $i -lt $words.Count; $i++) {
+		if ($valueFlags -contains $words[$i]) {
+			$i++
+		} elseif (-not $words[$i].StartsWith('-')) {
+			if ($commands.ContainsKey($words[$i])) {
+				$candidates = $commands[$words[$i]]
+			}
+			break
+		}
+	}
+	if ($words.Count -gt 0 -and $values.ContainsKey($words[-1])) {
+		$candidates = $values[$words[-1]]
+	}
+
+	$candidates | Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+		[System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+	}
+}
+{{ end }}
+`))
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSpec = completionSpec{
+	Flags: []completionFlag{
+		{Name: "debug", IsBool: true},
+		{Name: "db", IsFile: true},
+		{Name: "l", Values: []string{"br", "us"}},
+	},
+	Commands: []completedCommand{
+		{Name: "add-phrases", Flags: []completionFlag{{Name: "language", Usage: "It's the language", Values: []string{"br", "us", "auto"}}}},
+		{Name: "db", Actions: []string{"backup", "restore"}},
+	},
+}
+
+func TestCompletion(t *testing.T) {
+	for _, shell := range shells {
+		t.Run(shell, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := completion(&out, shell, testSpec); err != nil {
+				t.Fatalf("completion: %v", err)
+			}
+			for _, want := range []string{"add-phrases", "backup", "auto", "debug"} {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("completion doesn't have %q:\n%s", want, out.String())
+				}
+			}
+		})
+	}
+
+	if err := completion(&bytes.Buffer{}, "tcsh", testSpec); err == nil {
+		t.Error("completion tcsh: want error")
+	}
+}
+
+func TestBashCompletion(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not found")
+	}
+
+	var script bytes.Buffer
+	if err := completion(&script, "bash", testSpec); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		words string
+		want  string
+	}{
+		{"motivar ''", "add-phrases db -debug -db -l"},
+		{"motivar -l ''", "br us"},
+		{"motivar -l us d", "db"},
+		{"motivar -l us db ''", "backup restore"},
+		{"motivar add-phrases -language a", "auto"},
+	}
+	for _, tt := range tests {
+		cmd := exec.Command(bash, "-c", script.String()+`
+COMP_WORDS=(`+tt.words+`)
+COMP_CWORD=$((${#COMP_WORDS[@]} - 1))
+_motivar
+echo "${COMPREPLY[*]}"`)
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.words, err)
+		}
+		if got := strings.TrimSpace(string(out)); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.words, got, tt.want)
+		}
+	}
+}
+
+func TestShellInit(t *testing.T) {
+	var out bytes.Buffer
+	err := shellInit(&out, "fish", FlagsInit{OncePer: 4 * time.Hour}, testSpec)
+	if err != nil {
+		t.Fatalf("shellInit: %v", err)
+	}
+	if !strings.Contains(out.String(), "motivar -once-per 4h\n") {
+		t.Errorf("shellInit doesn't show a phrase once per 4h:\n%s", out.String())
+	}
+	if !strings.Contains(out.String(), "complete -c motivar") {
+		t.Errorf("shellInit doesn't have the completions:\n%s", out.String())
+	}
+
+	if err := shellInit(&bytes.Buffer{}, "tcsh", FlagsInit{}, testSpec); err == nil {
+		t.Error("shellInit tcsh: want error")
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		4 * time.Hour:                      "4h",
+		90 * time.Minute:                   "1h30m",
+		30 * time.Minute:                   "30m",
+		45 * time.Second:                   "45s",
+		time.Hour + 30*time.Second:         "1h0m30s",
+		2*time.Hour + 500*time.Millisecond: "2h0m0.5s",
+	}
+	for d, want := range tests {
+		if got := formatDuration(d); got != want {
+			t.Errorf("formatDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestClaimInterval(t *testing.T) {
+	file := filepath.Join(t.TempDir(), lastShownFile)
+	now := time.Now()
+	failed := errors.New("no phrase")
+
+	steps := []struct {
+		at      time.Time
+		showErr error
+		want    bool
+	}{
+		{now, nil, true},
+		{now.Add(time.Hour), nil, false},
+		// A failure to show isn't saved as shown.
+		{now.Add(4 * time.Hour), failed, true},
+		{now.Add(5 * time.Hour), nil, true},
+		{now.Add(6 * time.Hour), nil, false},
+		// The clock went back.
+		{now, nil, true},
+	}
+	for i, step := range steps {
+		var shown bool
+		got, err := claimInterval(file, 4*time.Hour, step.at, func() error {
+			shown = true
+			return step.showErr
+		})
+		if err != step.showErr {
+			t.Fatalf("step %d: got error %v, want %v", i, err, step.showErr)
+		}
+		if got != step.want || shown != step.want {
+			t.Errorf("step %d: claimInterval = %v, shown %v, want %v", i, got, shown, step.want)
+		}
+	}
+}
+
+func TestClaimIntervalLock(t *testing.T) {
+	file := filepath.Join(t.TempDir(), lastShownFile)
+	lock := file + ".lock"
+	if err := os.WriteFile(lock, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	show := func() error { return nil }
+
+	got, err := claimInterval(file, time.Hour, time.Now(), show)
+	if err != nil || got {
+		t.Fatalf("claimInterval with lock = %v, %v, want false", got, err)
+	}
+
+	// A lock left by a killed process is ignored.
+	got, err = claimInterval(file, time.Hour, time.Now().Add(lockTimeout), show)
+	if err != nil || !got {
+		t.Fatalf("claimInterval with old lock = %v, %v, want true", got, err)
+	}
+	if _, err := os.Stat(lock); !os.IsNotExist(err) {
+		t.Errorf("lock not removed: %v", err)
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// startupBudget is the max median time of running motivar to show a phrase.
+// It runs on every new shell, so it must feel instantaneous.
+const startupBudget = 100 * time.Millisecond
+
+const startupRuns = 15
+
+// buildBinary builds motivar in a temporary folder.
+func buildBinary(tb testing.TB) string {
+	tb.Helper()
+
+	if _, err := exec.LookPath("go"); err != nil {
+		tb.Skip("go command not found")
+	}
+
+	binary := filepath.Join(tb.TempDir(), "motivar")
+	out, err := exec.Command("go", "build", "-o", binary, ".").CombinedOutput()
+	if err != nil {
+		tb.Fatalf("go build: %v\n%s", err, out)
+	}
+	return binary
+}
+
+// runBinary runs motivar with its files in home, failing on error.
+func runBinary(tb testing.TB, binary, home string) time.Duration {
+	tb.Helper()
+
+	cmd := exec.Command(binary)
+	cmd.Env = append(os.Environ(), "MOTIVAR_HOME="+home, "MOTIVAR_DB=")
+
+	start := time.Now()
+	out, err := cmd.CombinedOutput()
+	elapsed := time.Since(start)
+	if err != nil || len(out) == 0 {
+		tb.Fatalf("motivar: %v\n%s", err, out)
+	}
+	return elapsed
+}
+
+// TestStartupLatency runs only with MOTIVAR_PERF=1, as timings on shared or
+// loaded machines would make it fail randomly.
+func TestStartupLatency(t *testing.T) {
+	if os.Getenv("MOTIVAR_PERF") != "1" {
+		t.Skip("set MOTIVAR_PERF=1 to measure the startup")
+	}
+
+	binary := buildBinary(t)
+	home := t.TempDir()
+
+	// The first run creates the files.
+	runBinary(t, binary, home)
+
+	durations := make([]time.Duration, startupRuns)
+	for i := range durations {
+		durations[i] = runBinary(t, binary, home)
+	}
+	slices.Sort(durations)
+
+	median := durations[len(durations)/2]
+	t.Logf("median %v, min %v, max %v", median, durations[0], durations[len(durations)-1])
+	if median > startupBudget {
+		t.Errorf("median startup %v exceeds the budget of %v", median, startupBudget)
+	}
+}
+
+func BenchmarkStartup(b *testing.B) {
+	binary := buildBinary(b)
+	home := b.TempDir()
+	runBinary(b, binary, home)
+
+	b.ResetTimer()
+	for b.Loop() {
+		runBinary(b, binary, home)
+	}
+}
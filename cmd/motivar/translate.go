@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wvoliveira/motivar"
+)
+
+var translateCommand = &command{
+	Name:  "translate",
+	Args:  "<action> [args]",
+	Short: "Link phrases that are translations of each other",
+	Long: `Actions:
+  find <text>       Show phrases, with their ids, containing text
+  link <id> <id>    Link two phrases of different languages
+  unlink <id>       Remove the phrase from its translations
+`,
+	Actions: []string{"find", "link", "unlink"},
+	Run:     translate,
+}
+
+// translate runs the translate command with args after "translate".
+func translate(ctx context.Context, client *motivar.Client, args []string) error {
+	action, args := args[0], args[1:]
+	switch action {
+	case "find":
+		if len(args) == 0 {
+			return usagef("usage: translate find <text>")
+		}
+		query := strings.Join(args, " ")
+
+		for _, language := range motivar.Languages() {
+			phrases, err := client.Search(ctx, query, motivar.Options{Language: language})
+			if err != nil {
+				return err
+			}
+			for _, p := range phrases {
+				fmt.Printf("%d [%s] %s\n", p.ID, p.Language, formatPhrase(p))
+			}
+		}
+		return nil
+	case "link":
+		if len(args) != 2 {
+			return usagef("usage: translate link <id> <id>")
+		}
+		phrases, err := phrasesByID(ctx, client, args)
+		if err != nil {
+			return err
+		}
+		return client.Link(ctx, phrases[0], phrases[1])
+	case "unlink":
+		if len(args) != 1 {
+			return usagef("usage: translate unlink <id>")
+		}
+		phrases, err := phrasesByID(ctx, client, args)
+		if err != nil {
+			return err
+		}
+		return client.Unlink(ctx, phrases[0])
+	}
+	return nil
+}
+
+func phrasesByID(ctx context.Context, client *motivar.Client, ids []string) ([]motivar.Phrase, error) {
+	phrases := make([]motivar.Phrase, len(ids))
+	for i, value := range ids {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, usagef("invalid phrase id %q", value)
+		}
+
+		phrases[i], err = client.Get(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("phrase %d: %w", id, err)
+		}
+	}
+	return phrases, nil
+}
+
+// printBilingual prints a random phrase of the first language followed by
+// its translation to the second one, when there is one.
+func printBilingual(ctx context.Context, client *motivar.Client, languages []string) error {
+	phrase, translation, err := client.RandomTranslated(ctx, motivar.Options{Language: languages[0]}, languages[1])
+	if err != nil && !errors.Is(err, motivar.ErrNotFound) {
+		return err
+	}
+
+	printPhrase(phrase)
+	if err == nil {
+		printPhrase(translation)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package motivar
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"os"
+	"time"
+)
+
+// The number of database phrases of each language is cached in a file next
+// to the database. When it says a language has no phrases, Random doesn't
+// open the database at all. The cache is removed after changing phrases, and
+// is valid while the database file keeps the same size and modification
+// time. The write-ahead log is left out: it's created and removed by every
+// process opening the database.
+
+type phraseCounts struct {
+	Database fileSignature  `json:"database"`
+	Counts   map[string]int `json:"counts"`
+}
+
+type fileSignature struct {
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+func (c *Client) countsFile() string {
+	return c.dbPath + ".counts"
+}
+
+// dbSignature returns the size and modification time of the database.
+func (c *Client) dbSignature() fileSignature {
+	info, err := os.Stat(c.dbPath)
+	if err != nil {
+		return fileSignature{}
+	}
+	return fileSignature{Size: info.Size(), ModTime: info.ModTime().UTC()}
+}
+
+// invalidateCounts removes the cache after phrases are added or removed.
+func (c *Client) invalidateCounts() {
+	err := os.Remove(c.countsFile())
+	if err != nil && !os.IsNotExist(err) {
+		c.logger.Debug("Removing phrase counts: " + err.Error())
+	}
+}
+
+// cachedCount returns how many database phrases language has, reading the
+// database only when the cache is missing or outdated.
+func (c *Client) cachedCount(ctx context.Context, language string) (int, error) {
+	signature := c.dbSignature()
+
+	if content, err := os.ReadFile(c.countsFile()); err == nil {
+		var cache phraseCounts
+		if json.Unmarshal(content, &cache) == nil && cache.Database.equal(signature) {
+			return cache.Counts[language], nil
+		}
+	}
+
+	db, err := c.database(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Opening the database may have created or migrated it, with the changes
+	// still in the log. Move them to the database file first, so its
+	// signature doesn't change when the log is checkpointed on close.
+	if _, err = db.conn.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+		c.logger.Debug("Checkpointing database: " + err.Error())
+	}
+
+	before := c.dbSignature()
+	counts, err := db.countPhrases(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Another process changed the database while counting, and the counts
+	// may miss rows that the signature covers.
+	if after := c.dbSignature(); !after.equal(before) {
+		return counts[language], nil
+	}
+
+	cache := phraseCounts{Database: before, Counts: counts}
+	if content, err := json.Marshal(cache); err == nil {
+		if err = os.WriteFile(c.countsFile(), content, 0664); err != nil {
+			c.logger.Debug("Saving phrase counts: " + err.Error())
+		}
+	}
+	return counts[language], nil
+}
+
+// randomFromDatabase returns a random database phrase of language within
+// limits, or sql.ErrNoRows if there is none.
+func (c *Client) randomFromDatabase(ctx context.Context, language string, limits lengthLimits) (Phrase, error) {
+	count, err := c.cachedCount(ctx, language)
+	if err != nil {
+		return Phrase{}, err
+	}
+	if count == 0 {
+		return Phrase{}, sql.ErrNoRows
+	}
+
+	db, err := c.database(ctx)
+	if err != nil {
+		return Phrase{}, err
+	}
+	return db.GetRandomPhrase(ctx, language, limits)
+}
+
+func (s fileSignature) equal(other fileSignature) bool {
+	return s.Size == other.Size && s.ModTime.Equal(other.ModTime)
+}
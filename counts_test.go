@@ -0,0 +1,45 @@
+package motivar
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestRandomSkipsEmptyDatabase(t *testing.T) {
+	ctx := context.Background()
+	dbFile := filepath.Join(t.TempDir(), "database.db")
+
+	// The first run counts the phrases, creating the database and the cache.
+	first := newTestClient(t, WithDBPath(dbFile))
+	if _, err := first.cachedCount(ctx, "us"); err != nil {
+		t.Fatal(err)
+	}
+	first.Close()
+
+	client := newTestClient(t, WithDBPath(dbFile))
+	for range 50 {
+		phrase, err := client.Random(ctx, Options{Language: "us"})
+		if err != nil || phrase.Phrase == "" {
+			t.Fatalf("Random: got %+v, %v", phrase, err)
+		}
+	}
+	if client.db != nil {
+		t.Fatal("Random opened the database without phrases")
+	}
+
+	server := httptest.NewServer(http.FileServer(http.Dir("samples")))
+	defer server.Close()
+
+	importer := newTestClient(t, WithDBPath(dbFile), WithHTTPClient(server.Client()))
+	if _, err := importer.Import(ctx, Source{URL: server.URL + "/quotes-us.json", Format: "json", Language: "us"}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	count, err := client.cachedCount(ctx, "us")
+	if err != nil || count == 0 {
+		t.Errorf("cachedCount after Import: got %d, %v", count, err)
+	}
+}
@@ -1,407 +1,502 @@
 // Code generated by go generate; DO NOT EDIT.
-// This file was generated by robots at
-// 2025-05-11 22:11:56.3174204 -0300 -03 m=+0.002064301
+
 package data
 
+// PhrasesBR embedded phrases of language "br"
 var PhrasesBR = []Phrase{
 	{
-		Phrase: "A persistência é o caminho do êxito.",
-		Author: "Charles Chaplin",
-	},
-	{
-		Phrase: "As pessoas costumam dizer que a motivação não dura sempre. Bem, nem o efeito do banho, por isso recomenda-se diariamente.",
-		Author: "Zig Ziglar",
+		Phrase:   "A persistência é o caminho do êxito.",
+		Author:   "Charles Chaplin",
+		Language: "br",
 	},
 	{
-		Phrase: "Motivação é a arte de fazer as pessoas fazerem o que você quer que elas façam porque elas o querem fazer.",
-		Author: "Dwight Eisenhower",
+		Phrase:   "As pessoas costumam dizer que a motivação não dura sempre. Bem, nem o efeito do banho, por isso recomenda-se diariamente.",
+		Author:   "Zig Ziglar",
+		Language: "br",
 	},
 	{
-		Phrase: "Toda ação humana, quer se torne positiva ou negativa, precisa depender de motivação.",
-		Author: "Dalai Lama",
+		Phrase:   "Motivação é a arte de fazer as pessoas fazerem o que você quer que elas façam porque elas o querem fazer.",
+		Author:   "Dwight Eisenhower",
+		Language: "br",
 	},
 	{
-		Phrase: "No meio da dificuldade encontra-se a oportunidade.",
-		Author: "Desconhecido",
+		Phrase:   "Toda ação humana, quer se torne positiva ou negativa, precisa depender de motivação.",
+		Author:   "Dalai Lama",
+		Language: "br",
 	},
 	{
-		Phrase: "Lute. Acredite. Conquiste. Perca. Deseje. Espere. Alcance. Invada. Caia. Seja tudo o quiser ser, mas acima de tudo, seja você sempre.",
-		Author: "Tumblr",
+		Phrase:   "No meio da dificuldade encontra-se a oportunidade.",
+		Author:   "Desconhecido",
+		Language: "br",
 	},
 	{
-		Phrase: "Eu faço da dificuldade a minha motivação. A volta por cima vem na continuação.",
-		Author: "Charlie Brown Jr",
+		Phrase:   "Lute. Acredite. Conquiste. Perca. Deseje. Espere. Alcance. Invada. Caia. Seja tudo o quiser ser, mas acima de tudo, seja você sempre.",
+		Author:   "Tumblr",
+		Language: "br",
 	},
 	{
-		Phrase: "A verdadeira motivação vem de realização, desenvolvimento pessoal, satisfação no trabalho e reconhecimento.",
-		Author: "Frederick Herzberg",
+		Phrase:   "Eu faço da dificuldade a minha motivação. A volta por cima vem na continuação.",
+		Author:   "Charlie Brown Jr",
+		Language: "br",
 	},
 	{
-		Phrase: "Pedras no caminho? Eu guardo todas. Um dia vou construir um castelo.",
-		Author: "Nemo Nox",
+		Phrase:   "A verdadeira motivação vem de realização, desenvolvimento pessoal, satisfação no trabalho e reconhecimento.",
+		Author:   "Frederick Herzberg",
+		Language: "br",
 	},
 	{
-		Phrase: "É parte da cura o desejo de ser curado.",
-		Author: "Sêneca",
+		Phrase:   "Pedras no caminho? Eu guardo todas. Um dia vou construir um castelo.",
+		Author:   "Nemo Nox",
+		Language: "br",
 	},
 	{
-		Phrase: "Tudo o que um sonho precisa para ser realizado é alguém que acredite que ele possa ser realizado.",
-		Author: "Roberto Shinyashiki",
+		Phrase:   "É parte da cura o desejo de ser curado.",
+		Author:   "Sêneca",
+		Language: "br",
 	},
 	{
-		Phrase: "O que me preocupa não é o grito dos maus. É o silêncio dos bons.",
-		Author: "Martin Luther King",
+		Phrase:   "Tudo o que um sonho precisa para ser realizado é alguém que acredite que ele possa ser realizado.",
+		Author:   "Roberto Shinyashiki",
+		Language: "br",
 	},
 	{
-		Phrase: "Imagine uma nova história para sua vida e acredite nela.",
-		Author: "Paulo Coelho",
+		Phrase:   "O que me preocupa não é o grito dos maus. É o silêncio dos bons.",
+		Author:   "Martin Luther King",
+		Language: "br",
 	},
 	{
-		Phrase: "O insucesso é apenas uma oportunidade para recomeçar com mais inteligência.",
-		Author: "Henry Ford",
+		Phrase:   "Imagine uma nova história para sua vida e acredite nela.",
+		Author:   "Paulo Coelho",
+		Language: "br",
 	},
 	{
-		Phrase: "Quando você quer alguma coisa, todo o universo conspira para que você realize o seu desejo.",
-		Author: "Paulo Coelho",
+		Phrase:   "O insucesso é apenas uma oportunidade para recomeçar com mais inteligência.",
+		Author:   "Henry Ford",
+		Language: "br",
 	},
 	{
-		Phrase: "Você precisa fazer aquilo que pensa que não é capaz de fazer.",
-		Author: "Eleanor Roosevelt",
+		Phrase:   "Quando você quer alguma coisa, todo o universo conspira para que você realize o seu desejo.",
+		Author:   "Paulo Coelho",
+		Language: "br",
 	},
 	{
-		Phrase: "O sucesso é ir de fracasso em fracasso sem perder entusiasmo.",
-		Author: "Winston Churchill",
+		Phrase:   "Você precisa fazer aquilo que pensa que não é capaz de fazer.",
+		Author:   "Eleanor Roosevelt",
+		Language: "br",
 	},
 	{
-		Phrase: "Só se pode alcançar um grande êxito quando nos mantemos fiéis a nós mesmos.",
-		Author: "Friedrich Nietzsche",
+		Phrase:   "O sucesso é ir de fracasso em fracasso sem perder entusiasmo.",
+		Author:   "Winston Churchill",
+		Language: "br",
 	},
 	{
-		Phrase: "Lute com determinação, abrace a vida com paixão, perca com classe e vença com ousadia, porque o mundo pertence a quem se atreve e a vida é muito para ser insignificante.",
-		Author: "Augusto Branco",
+		Phrase:   "Só se pode alcançar um grande êxito quando nos mantemos fiéis a nós mesmos.",
+		Author:   "Friedrich Nietzsche",
+		Language: "br",
 	},
 	{
-		Phrase: "Nossa maior fraqueza está em desistir. O caminho mais certo de vencer é tentar mais uma vez.",
-		Author: "Thomas Edison",
+		Phrase:   "Lute com determinação, abrace a vida com paixão, perca com classe e vença com ousadia, porque o mundo pertence a quem se atreve e a vida é muito para ser insignificante.",
+		Author:   "Augusto Branco",
+		Language: "br",
 	},
 	{
-		Phrase: "O sucesso nasce do querer, da determinação e persistência em se chegar a um objetivo. Mesmo não atingindo o alvo, quem busca e vence obstáculos, no mínimo fará coisas admiráveis.",
-		Author: "José de Alencar",
+		Phrase:   "Nossa maior fraqueza está em desistir. O caminho mais certo de vencer é tentar mais uma vez.",
+		Author:   "Thomas Edison",
+		Language: "br",
 	},
 	{
-		Phrase: "Se você quer ser bem-sucedido precisa de dedicação total, buscar seu último limite e dar o melhor de si mesmo.",
-		Author: "Ayrton Senna",
+		Phrase:   "O sucesso nasce do querer, da determinação e persistência em se chegar a um objetivo. Mesmo não atingindo o alvo, quem busca e vence obstáculos, no mínimo fará coisas admiráveis.",
+		Author:   "José de Alencar",
+		Language: "br",
 	},
 	{
-		Phrase: "Não crie limites para si mesmo. Você deve ir tão longe quanto sua mente permitir. O que você mais quer pode ser conquistado.",
-		Author: "Mary Kay Ash",
+		Phrase:   "Se você quer ser bem-sucedido precisa de dedicação total, buscar seu último limite e dar o melhor de si mesmo.",
+		Author:   "Ayrton Senna",
+		Language: "br",
 	},
 	{
-		Phrase: "Nenhum obstáculo será grande se a sua vontade de vencer for maior.",
-		Author: "Autor desconhecido",
+		Phrase:   "Não crie limites para si mesmo. Você deve ir tão longe quanto sua mente permitir. O que você mais quer pode ser conquistado.",
+		Author:   "Mary Kay Ash",
+		Language: "br",
 	},
 	{
-		Phrase: "Dificuldades preparam pessoas comuns para destinos extraordinários.",
-		Author: " C.S Lewis",
+		Phrase:   "Nenhum obstáculo será grande se a sua vontade de vencer for maior.",
+		Author:   "Autor desconhecido",
+		Language: "br",
 	},
 	{
-		Phrase: "Nenhum homem será um grande líder se quiser fazer tudo sozinho ou se quiser levar todo o crédito por fazer isso.",
-		Author: "Andrew Carnegie",
+		Phrase:   "Dificuldades preparam pessoas comuns para destinos extraordinários.",
+		Author:   "C.S Lewis",
+		Language: "br",
 	},
 	{
-		Phrase: "Bom mesmo é ir à luta com determinação, abraçar a vida com paixão, perder com classe e vencer com ousadia, porque o mundo pertence a quem se atreve e a vida é muito curta, para ser insignificante.",
-		Author: "Charlie Chaplin",
+		Phrase:   "Nenhum homem será um grande líder se quiser fazer tudo sozinho ou se quiser levar todo o crédito por fazer isso.",
+		Author:   "Andrew Carnegie",
+		Language: "br",
 	},
 	{
-		Phrase: "Pessoas vencedoras não são aquelas que não falham, são aquelas que não desistem.",
-		Author: "Autor desconhecido",
+		Phrase:   "Bom mesmo é ir à luta com determinação, abraçar a vida com paixão, perder com classe e vencer com ousadia, porque o mundo pertence a quem se atreve e a vida é muito curta, para ser insignificante.",
+		Author:   "Charlie Chaplin",
+		Language: "br",
 	},
 	{
-		Phrase: "Só existem dois dias do ano em que você não pode fazer nada: um se chama ontem e outro amanhã.",
-		Author: "Dalai Lama",
+		Phrase:   "Pessoas vencedoras não são aquelas que não falham, são aquelas que não desistem.",
+		Author:   "Autor desconhecido",
+		Language: "br",
 	},
 	{
-		Phrase: "A vida é um constante recomeço. Não se dê por derrotado e siga adiante. As pedras que hoje atrapalham sua caminhada amanhã enfeitarão a sua estrada.",
-		Author: " Autor desconhecido",
+		Phrase:   "Só existem dois dias do ano em que você não pode fazer nada: um se chama ontem e outro amanhã.",
+		Author:   "Dalai Lama",
+		Language: "br",
 	},
 	{
-		Phrase: "Ouse ir além, ouse fazer diferente e o poder lhe será dado!.",
-		Author: "José Roberto Marques",
+		Phrase:   "A vida é um constante recomeço. Não se dê por derrotado e siga adiante. As pedras que hoje atrapalham sua caminhada amanhã enfeitarão a sua estrada.",
+		Author:   "Autor desconhecido",
+		Language: "br",
 	},
 	{
-		Phrase: "Ouse, arrisque, não desista jamais e saiba valorizar quem te ama, esses sim merecem seu respeito. Quanto ao resto, bom, ninguém nunca precisou de restos para ser feliz.",
-		Author: "Clarice Lispector",
+		Phrase:   "Ouse ir além, ouse fazer diferente e o poder lhe será dado!.",
+		Author:   "José Roberto Marques",
+		Language: "br",
 	},
 	{
-		Phrase: "Para ser um campeão você tem que acreditar em si mesmo quando ninguém mais acredita.",
-		Author: "Muhammad Ali",
+		Phrase:   "Ouse, arrisque, não desista jamais e saiba valorizar quem te ama, esses sim merecem seu respeito. Quanto ao resto, bom, ninguém nunca precisou de restos para ser feliz.",
+		Author:   "Clarice Lispector",
+		Language: "br",
 	},
 	{
-		Phrase: "No fim tudo dá certo, e se não deu certo é porque ainda não chegou ao fim.",
-		Author: "Fernando Sabino",
+		Phrase:   "Para ser um campeão você tem que acreditar em si mesmo quando ninguém mais acredita.",
+		Author:   "Muhammad Ali",
+		Language: "br",
 	},
 	{
-		Phrase: "Você nunca sabe que resultados virão da sua ação. Mas se você não fizer nada, não existirão resultados.",
-		Author: "Mahatma Gandhi",
+		Phrase:   "No fim tudo dá certo, e se não deu certo é porque ainda não chegou ao fim.",
+		Author:   "Fernando Sabino",
+		Language: "br",
 	},
 	{
-		Phrase: "O pessimista vê dificuldade em toda oportunidade. O otimista vê oportunidade em toda dificuldade.",
-		Author: "Winston Churchill",
+		Phrase:   "Você nunca sabe que resultados virão da sua ação. Mas se você não fizer nada, não existirão resultados.",
+		Author:   "Mahatma Gandhi",
+		Language: "br",
 	},
 	{
-		Phrase: "A paciência é um elemento fundamental do sucesso.",
-		Author: "Bill Gates",
+		Phrase:   "O pessimista vê dificuldade em toda oportunidade. O otimista vê oportunidade em toda dificuldade.",
+		Author:   "Winston Churchill",
+		Language: "br",
 	},
 	{
-		Phrase: "Reclamar não é uma estratégia. É necessário lidarmos com o mundo como ele é e não como gostaríamos que ele fosse.",
-		Author: "Jeff Bezos",
+		Phrase:   "A paciência é um elemento fundamental do sucesso.",
+		Author:   "Bill Gates",
+		Language: "br",
 	},
 	{
-		Phrase: "O sucesso não tem a ver com o lugar de onde você veio, e sim com a confiança que você tem e o esforço que você está disposto a investir.",
-		Author: "Michelle Obama",
+		Phrase:   "Reclamar não é uma estratégia. É necessário lidarmos com o mundo como ele é e não como gostaríamos que ele fosse.",
+		Author:   "Jeff Bezos",
+		Language: "br",
 	},
 	{
-		Phrase: "Você pode encarar um erro como uma besteira a ser esquecida, ou como um resultado que aponta uma nova direção.",
-		Author: "Steve Jobs",
+		Phrase:   "O sucesso não tem a ver com o lugar de onde você veio, e sim com a confiança que você tem e o esforço que você está disposto a investir.",
+		Author:   "Michelle Obama",
+		Language: "br",
 	},
 	{
-		Phrase: "Você não pode ser uma pessoa difícil, tímida, que não é capaz de olhar alguém nos olhos; você tem que se apresentar. Você tem que saber como falar sobre si mesmo, sua visão, o seu foco e em que você acredita.",
-		Author: "Anna Wintour",
+		Phrase:   "Você pode encarar um erro como uma besteira a ser esquecida, ou como um resultado que aponta uma nova direção.",
+		Author:   "Steve Jobs",
+		Language: "br",
 	},
 	{
-		Phrase: "Eu posso aceitar a falha, todos falham em alguma coisa. Mas eu não posso aceitar não tentar.",
-		Author: "Michael Jordan",
+		Phrase:   "Você não pode ser uma pessoa difícil, tímida, que não é capaz de olhar alguém nos olhos; você tem que se apresentar. Você tem que saber como falar sobre si mesmo, sua visão, o seu foco e em que você acredita.",
+		Author:   "Anna Wintour",
+		Language: "br",
 	},
 	{
-		Phrase: "Gostaria que você soubesse que existe dentro de si uma força capaz de mudar sua vida. Basta que lute e aguarde um novo amanhecer.",
-		Author: "Margaret Thatcher",
+		Phrase:   "Eu posso aceitar a falha, todos falham em alguma coisa. Mas eu não posso aceitar não tentar.",
+		Author:   "Michael Jordan",
+		Language: "br",
 	},
 	{
-		Phrase: "Inteligência é a capacidade de se adaptar às mudanças.",
-		Author: "Stephen Hawking",
+		Phrase:   "Gostaria que você soubesse que existe dentro de si uma força capaz de mudar sua vida. Basta que lute e aguarde um novo amanhecer.",
+		Author:   "Margaret Thatcher",
+		Language: "br",
 	},
 	{
-		Phrase: "É preciso ser protagonista. Não dá para ficar só ouvindo a banda passar, temos de ser parte da banda.",
-		Author: "Sônia Hess",
+		Phrase:   "Inteligência é a capacidade de se adaptar às mudanças.",
+		Author:   "Stephen Hawking",
+		Language: "br",
 	},
 	{
-		Phrase: "A arte de ser ora audacioso, ora prudente, é a arte de vencer.",
-		Author: "Napoleão Bonaparte",
+		Phrase:   "É preciso ser protagonista. Não dá para ficar só ouvindo a banda passar, temos de ser parte da banda.",
+		Author:   "Sônia Hess",
+		Language: "br",
 	},
 	{
-		Phrase: "Nossos fracassos, às vezes, são mais frutíferos do que os êxitos.",
-		Author: "Henry Ford",
+		Phrase:   "A arte de ser ora audacioso, ora prudente, é a arte de vencer.",
+		Author:   "Napoleão Bonaparte",
+		Language: "br",
 	},
 	{
-		Phrase: "Comemore os seus sucessos. Veja com humor os seus fracassos.",
-		Author: "Sam Walton",
+		Phrase:   "Nossos fracassos, às vezes, são mais frutíferos do que os êxitos.",
+		Author:   "Henry Ford",
+		Language: "br",
 	},
 	{
-		Phrase: "Não somos responsáveis apenas pelo que fazemos, mas também pelo que deixamos de fazer.",
-		Author: "Moliere",
+		Phrase:   "Comemore os seus sucessos. Veja com humor os seus fracassos.",
+		Author:   "Sam Walton",
+		Language: "br",
 	},
 	{
-		Phrase: "É costume de um tolo, quando erra, queixar-se dos outros. É costume de um sábio queixar-se de si mesmo.",
-		Author: "Sócrates",
+		Phrase:   "Não somos responsáveis apenas pelo que fazemos, mas também pelo que deixamos de fazer.",
+		Author:   "Moliere",
+		Language: "br",
 	},
 	{
-		Phrase: "Existe o risco que você jamais pode correr. Existe o risco que você jamais pode deixar de correr.",
-		Author: "Peter Drucker",
+		Phrase:   "É costume de um tolo, quando erra, queixar-se dos outros. É costume de um sábio queixar-se de si mesmo.",
+		Author:   "Sócrates",
+		Language: "br",
 	},
 	{
-		Phrase: "Mesmo que já tenhas feito uma longa caminhada, há sempre um novo caminho a fazer.",
-		Author: "Santo Agostinho",
+		Phrase:   "Existe o risco que você jamais pode correr. Existe o risco que você jamais pode deixar de correr.",
+		Author:   "Peter Drucker",
+		Language: "br",
 	},
 	{
-		Phrase: "A felicidade não está em fazer o que a gente quer, e sim querer o que a gente faz.",
-		Author: "Jean Paul Sartre",
+		Phrase:   "Mesmo que já tenhas feito uma longa caminhada, há sempre um novo caminho a fazer.",
+		Author:   "Santo Agostinho",
+		Language: "br",
 	},
 	{
-		Phrase: "É sempre divertido fazer o impossível.",
-		Author: "Walt Disney",
+		Phrase:   "A felicidade não está em fazer o que a gente quer, e sim querer o que a gente faz.",
+		Author:   "Jean Paul Sartre",
+		Language: "br",
 	},
 	{
-		Phrase: "Experiência é o nome que cada um dá a seus erros.",
-		Author: "Oscar Wilde",
+		Phrase:   "É sempre divertido fazer o impossível.",
+		Author:   "Walt Disney",
+		Language: "br",
 	},
 	{
-		Phrase: "Somente os que ousam errar muito podem realizar muito.",
-		Author: "John F. Kennedy",
+		Phrase:   "Experiência é o nome que cada um dá a seus erros.",
+		Author:   "Oscar Wilde",
+		Language: "br",
 	},
 	{
-		Phrase: "Somos o que repetidamente fazemos. Portanto, a excelência não é um feito, é um hábito.",
-		Author: "Aristóteles",
+		Phrase:   "Somente os que ousam errar muito podem realizar muito.",
+		Author:   "John F. Kennedy",
+		Language: "br",
 	},
 	{
-		Phrase: "Toda empresa precisa ter gente que erra, que não tem medo de errar e que aprende com erro.",
-		Author: "Bill Gates",
+		Phrase:   "Somos o que repetidamente fazemos. Portanto, a excelência não é um feito, é um hábito.",
+		Author:   "Aristóteles",
+		Language: "br",
 	},
 	{
-		Phrase: "A confiança em si mesmo é o primeiro segredo do sucesso.",
-		Author: "Ralph Waldo Emerson",
+		Phrase:   "Toda empresa precisa ter gente que erra, que não tem medo de errar e que aprende com erro.",
+		Author:   "Bill Gates",
+		Language: "br",
 	},
 	{
-		Phrase: "Aquele que pretende ser um líder tem que ser uma ponte.",
-		Author: "Provérbio Galês",
+		Phrase:   "A confiança em si mesmo é o primeiro segredo do sucesso.",
+		Author:   "Ralph Waldo Emerson",
+		Language: "br",
 	},
 	{
-		Phrase: "Muda tuas ideias e mudarás teu mundo.",
-		Author: "Norman Vincent Peale",
+		Phrase:   "Aquele que pretende ser um líder tem que ser uma ponte.",
+		Author:   "Provérbio Galês",
+		Language: "br",
 	},
 	{
-		Phrase: "A vitória sempre foi de quem nunca duvidou dela.",
-		Author: "Raul Follerean",
+		Phrase:   "Muda tuas ideias e mudarás teu mundo.",
+		Author:   "Norman Vincent Peale",
+		Language: "br",
 	},
 	{
-		Phrase: "Se existe uma forma de fazer melhor, descubra-a.",
-		Author: "Thomas Edison",
+		Phrase:   "A vitória sempre foi de quem nunca duvidou dela.",
+		Author:   "Raul Follerean",
+		Language: "br",
 	},
 	{
-		Phrase: "O problema é que a maioria das pessoas prefere um elogio que prejudique do que uma crítica que beneficie.",
-		Author: "Norman Vincent Peale",
+		Phrase:   "Se existe uma forma de fazer melhor, descubra-a.",
+		Author:   "Thomas Edison",
+		Language: "br",
 	},
 	{
-		Phrase: "A sorte favorece a mente preparada.",
-		Author: "Louis Pasteur",
+		Phrase:   "O problema é que a maioria das pessoas prefere um elogio que prejudique do que uma crítica que beneficie.",
+		Author:   "Norman Vincent Peale",
+		Language: "br",
 	},
 	{
-		Phrase: "Não há sucesso sem dificuldade.",
-		Author: "Sófocles",
+		Phrase:   "A sorte favorece a mente preparada.",
+		Author:   "Louis Pasteur",
+		Language: "br",
 	},
 	{
-		Phrase: "A maior recompensa pelo trabalho não é o que a pessoa ganha, é o que ela se torna através dele.",
-		Author: "John Ruskin",
+		Phrase:   "Não há sucesso sem dificuldade.",
+		Author:   "Sófocles",
+		Language: "br",
 	},
 	{
-		Phrase: "Não encontre defeitos, encontre soluções. Qualquer um sabe queixar-se.",
-		Author: "Henry Ford",
+		Phrase:   "A maior recompensa pelo trabalho não é o que a pessoa ganha, é o que ela se torna através dele.",
+		Author:   "John Ruskin",
+		Language: "br",
 	},
 	{
-		Phrase: "Conhecimento não é aquilo que você sabe, mas o que você faz com aquilo que sabe.",
-		Author: "Aldous Huxley",
+		Phrase:   "Não encontre defeitos, encontre soluções. Qualquer um sabe queixar-se.",
+		Author:   "Henry Ford",
+		Language: "br",
 	},
 	{
-		Phrase: "Critica o que fazes, e não faças o que criticas.",
-		Author: "Provérbio Árabe",
+		Phrase:   "Conhecimento não é aquilo que você sabe, mas o que você faz com aquilo que sabe.",
+		Author:   "Aldous Huxley",
+		Language: "br",
 	},
 	{
-		Phrase: "Se sonhar grande dá o mesmo trabalho que sonhar pequeno, por que vou sonhar pequeno?.",
-		Author: "Jorge Paulo Lemann",
+		Phrase:   "Critica o que fazes, e não faças o que criticas.",
+		Author:   "Provérbio Árabe",
+		Language: "br",
 	},
 	{
-		Phrase: "Por vezes sentimos que aquilo que fazemos não é senão uma gota de água no mar. Mas o mar seria menor se lhe faltasse uma gota.",
-		Author: "Madre Teresa de Calcutá",
+		Phrase:   "Se sonhar grande dá o mesmo trabalho que sonhar pequeno, por que vou sonhar pequeno?.",
+		Author:   "Jorge Paulo Lemann",
+		Language: "br",
 	},
 	{
-		Phrase: "Destino não é exterior a nós; somos nós que criamos nosso próprio destino dia após dia.",
-		Author: "Henry Miller",
+		Phrase:   "Por vezes sentimos que aquilo que fazemos não é senão uma gota de água no mar. Mas o mar seria menor se lhe faltasse uma gota.",
+		Author:   "Madre Teresa de Calcutá",
+		Language: "br",
 	},
 	{
-		Phrase: "Ontem foi ontem, já passou. Hoje é hoje e é o que nos importa. Amanhã, o futuro, a Deus pertence.",
-		Author: "Samuel Klein",
+		Phrase:   "Destino não é exterior a nós; somos nós que criamos nosso próprio destino dia após dia.",
+		Author:   "Henry Miller",
+		Language: "br",
 	},
 	{
-		Phrase: "Você precisa fazer aquilo que pensa que não é capaz de fazer.",
-		Author: "Eleanor Roosevelt",
+		Phrase:   "Ontem foi ontem, já passou. Hoje é hoje e é o que nos importa. Amanhã, o futuro, a Deus pertence.",
+		Author:   "Samuel Klein",
+		Language: "br",
 	},
 	{
-		Phrase: "Faça o que puder, com o que tiver, onde estiver.",
-		Author: "Theodore Roosevelt",
+		Phrase:   "Faça o que puder, com o que tiver, onde estiver.",
+		Author:   "Theodore Roosevelt",
+		Language: "br",
 	},
 	{
-		Phrase: "A vida é curta demais. Não corra o risco de passar seus dias apenas afinando seu instrumento sem jamais fazer um grande espetáculo.",
-		Author: "Carlos Wizard Martins",
+		Phrase:   "A vida é curta demais. Não corra o risco de passar seus dias apenas afinando seu instrumento sem jamais fazer um grande espetáculo.",
+		Author:   "Carlos Wizard Martins",
+		Language: "br",
 	},
 	{
-		Phrase: "Tudo que você precisa fazer é mover as pessoas só um pouquinho para mudanças acontecerem. Não precisa ser algo enorme.",
-		Author: "Viola Davis",
+		Phrase:   "Tudo que você precisa fazer é mover as pessoas só um pouquinho para mudanças acontecerem. Não precisa ser algo enorme.",
+		Author:   "Viola Davis",
+		Language: "br",
 	},
 	{
-		Phrase: "Nossas dúvidas são traidoras e nos fazem perder, por medo de tentar, o que poderíamos ganhar.",
-		Author: "William Shakespeare",
+		Phrase:   "Nossas dúvidas são traidoras e nos fazem perder, por medo de tentar, o que poderíamos ganhar.",
+		Author:   "William Shakespeare",
+		Language: "br",
 	},
 	{
-		Phrase: "Se você quer fazer uma coisa realmente grande, seja grande como a coisa que você quer fazer.",
-		Author: "Nizan Guanaes",
+		Phrase:   "Se você quer fazer uma coisa realmente grande, seja grande como a coisa que você quer fazer.",
+		Author:   "Nizan Guanaes",
+		Language: "br",
 	},
 	{
-		Phrase: "Aquele que é feliz, espalha felicidade. Aquele que teima na infelicidade, que perde o equilíbrio e a confiança, perde-se na vida.",
-		Author: "Anne Frank",
+		Phrase:   "Aquele que é feliz, espalha felicidade. Aquele que teima na infelicidade, que perde o equilíbrio e a confiança, perde-se na vida.",
+		Author:   "Anne Frank",
+		Language: "br",
 	},
 	{
-		Phrase: "Os problemas são apenas oportunidades com roupas de trabalho.",
-		Author: "Henry John Kaiser",
+		Phrase:   "Os problemas são apenas oportunidades com roupas de trabalho.",
+		Author:   "Henry John Kaiser",
+		Language: "br",
 	},
 	{
-		Phrase: "Na crise, existem aqueles que se abatem, sentam no chão e choram; e existem aqueles que fabricam e vendem lenços. Nós somos fabricantes de lenços.",
-		Author: "Abilio Diniz",
+		Phrase:   "Na crise, existem aqueles que se abatem, sentam no chão e choram; e existem aqueles que fabricam e vendem lenços. Nós somos fabricantes de lenços.",
+		Author:   "Abilio Diniz",
+		Language: "br",
 	},
 	{
-		Phrase: "Levanto a minha voz, não para que eu possa gritar, mas para que aqueles sem voz possam ser ouvidos….",
-		Author: "Malala Yousafzai",
+		Phrase:   "Levanto a minha voz, não para que eu possa gritar, mas para que aqueles sem voz possam ser ouvidos….",
+		Author:   "Malala Yousafzai",
+		Language: "br",
 	},
 	{
-		Phrase: "Em todas as situações, deve-se considerar o objetivo.",
-		Author: "Jean de La Fontaine",
+		Phrase:   "Em todas as situações, deve-se considerar o objetivo.",
+		Author:   "Jean de La Fontaine",
+		Language: "br",
 	},
 	{
-		Phrase: "Se você quer saber o quanto você é forte, é na necessidade que descobrimos que somos gigantes.",
-		Author: "Cleusa Maria da Silva",
+		Phrase:   "Se você quer saber o quanto você é forte, é na necessidade que descobrimos que somos gigantes.",
+		Author:   "Cleusa Maria da Silva",
+		Language: "br",
 	},
 	{
-		Phrase: "Só se aprende com a experiência. Portanto, não importa o que as pessoas lhe digam, você tem que viver e cometer seus próprios erros para aprender.",
-		Author: "Emma Watson",
+		Phrase:   "Só se aprende com a experiência. Portanto, não importa o que as pessoas lhe digam, você tem que viver e cometer seus próprios erros para aprender.",
+		Author:   "Emma Watson",
+		Language: "br",
 	},
 	{
-		Phrase: "É o motivo que engrandece a ação; é o fazer, não o feito.",
-		Author: "Margaret Preston",
+		Phrase:   "É o motivo que engrandece a ação; é o fazer, não o feito.",
+		Author:   "Margaret Preston",
+		Language: "br",
 	},
 	{
-		Phrase: "Um grande líder é exemplo pela atitude, não pelo discurso.",
-		Author: "Robinson Shiba",
+		Phrase:   "Um grande líder é exemplo pela atitude, não pelo discurso.",
+		Author:   "Robinson Shiba",
+		Language: "br",
 	},
 	{
-		Phrase: "Sucesso é mais frequentemente alcançado por aqueles que não sabem que o fracasso é inevitável.",
-		Author: "Coco Chanel",
+		Phrase:   "Sucesso é mais frequentemente alcançado por aqueles que não sabem que o fracasso é inevitável.",
+		Author:   "Coco Chanel",
+		Language: "br",
 	},
 	{
-		Phrase: "Quem vive sem disciplina morre sem honra.",
-		Author: "Provérbio islandês",
+		Phrase:   "Quem vive sem disciplina morre sem honra.",
+		Author:   "Provérbio islandês",
+		Language: "br",
 	},
 	{
-		Phrase: "Um dia é preciso parar de sonhar, tirar os planos da gaveta e, de algum modo, começar.",
-		Author: "Amyr Klink",
+		Phrase:   "Um dia é preciso parar de sonhar, tirar os planos da gaveta e, de algum modo, começar.",
+		Author:   "Amyr Klink",
+		Language: "br",
 	},
 	{
-		Phrase: "A maior descoberta de todos os tempos é que uma pessoa pode mudar, simplesmente mudando de atitude.",
-		Author: "Oprah Winfrey",
+		Phrase:   "A maior descoberta de todos os tempos é que uma pessoa pode mudar, simplesmente mudando de atitude.",
+		Author:   "Oprah Winfrey",
+		Language: "br",
 	},
 	{
-		Phrase: "Mesmo se você estiver no caminho certo será atropelado se ficar sentado nele.",
-		Author: "Will Rogers",
+		Phrase:   "Mesmo se você estiver no caminho certo será atropelado se ficar sentado nele.",
+		Author:   "Will Rogers",
+		Language: "br",
 	},
 	{
-		Phrase: "O fundamental é manter sempre a mesma obsessão em alcançar o sucesso. Ter sucesso não é apenas ter dinheiro, mas sim saber que uma ideia que parece impossível pode vir a ser uma empresa que irá quebrar paradigmas.",
-		Author: "Romero Rodrigues",
+		Phrase:   "O fundamental é manter sempre a mesma obsessão em alcançar o sucesso. Ter sucesso não é apenas ter dinheiro, mas sim saber que uma ideia que parece impossível pode vir a ser uma empresa que irá quebrar paradigmas.",
+		Author:   "Romero Rodrigues",
+		Language: "br",
 	},
 	{
-		Phrase: "O jeito mais eficiente de fazer algo é fazendo.",
-		Author: "Amelia Earhart",
+		Phrase:   "O jeito mais eficiente de fazer algo é fazendo.",
+		Author:   "Amelia Earhart",
+		Language: "br",
 	},
 	{
-		Phrase: "O insucesso é uma oportunidade para recomeçar com mais inteligência.",
-		Author: "Henry Ford",
+		Phrase:   "O insucesso é uma oportunidade para recomeçar com mais inteligência.",
+		Author:   "Henry Ford",
+		Language: "br",
 	},
 	{
-		Phrase: "O liderado será reflexo da sua liderança, então quem espera lealdade, primeiro deve ser leal.",
-		Author: "Flávio Augusto.",
+		Phrase:   "O liderado será reflexo da sua liderança, então quem espera lealdade, primeiro deve ser leal.",
+		Author:   "Flávio Augusto.",
+		Language: "br",
 	},
 	{
-		Phrase: "Sozinhos, pouco podemos fazer; juntos, podemos fazer muito.",
-		Author: "Helen Keller",
+		Phrase:   "Sozinhos, pouco podemos fazer; juntos, podemos fazer muito.",
+		Author:   "Helen Keller",
+		Language: "br",
 	},
 	{
-		Phrase: "Uma atitude vitoriosa é meio caminho andado para o sucesso.",
-		Author: "Arthur Riedel",
+		Phrase:   "Uma atitude vitoriosa é meio caminho andado para o sucesso.",
+		Author:   "Arthur Riedel",
+		Language: "br",
 	},
 }
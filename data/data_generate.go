@@ -2,17 +2,39 @@
 // +build ignore
 
 // This file is necessary to create go file with data phrases.
+//
+// Every subdirectory of the data folder is a language (br, us, ...) and every
+// JSON file inside it holds a list of phrases. For each language a file
+// data_<language>.go is generated, plus languages.go with the registry of all
+// languages found. The output doesn't depend on time or on the order returned
+// by the filesystem, so running it twice produces the same files.
+//
+// Usage:
+//
+//	go run data/data_generate.go [-dir data] [-check]
+//
+// With -check nothing is written: it exits with error if any generated file is
+// missing or out of date.
 
 package main
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
+	"strconv"
+	"strings"
 	"text/template"
-	"time"
+	"unicode/utf8"
 )
 
 // Phrase phrases struct
@@ -21,82 +43,260 @@ type Phrase struct {
 	Author string `json:"author"`
 }
 
+type language struct {
+	Code     string
+	Variable string
+	Phrases  []Phrase
+}
+
+var languageCode = regexp.MustCompile(`^[a-z]{2,3}$`)
+
 func main() {
 	_, currentFile, _, _ := runtime.Caller(0)
-	currentDir := filepath.Dir(currentFile)
 
-	fileDataBR, err := os.Create(filepath.Join(currentDir, "data_br.go"))
+	dir := flag.String("dir", filepath.Dir(currentFile), "Data folder with one subfolder per language")
+	check := flag.Bool("check", false, "Only check if the generated files are up to date")
+	flag.Parse()
+
+	languages, err := ReadLanguages(*dir)
 	die(err)
-	defer fileDataBR.Close()
 
-	fileDataUS, err := os.Create(filepath.Join(currentDir, "data_us.go"))
+	files, err := Render(languages)
 	die(err)
-	defer fileDataUS.Close()
 
-	log.Println("Converting BR phrases")
-	phrasesBR := ReadPhrasesFromDirectory("data/br")
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
 
-	log.Println("Converting US phrases")
-	phrasesUS := ReadPhrasesFromDirectory("data/us")
+	if *check {
+		var stale []string
+		for _, name := range names {
+			current, err := os.ReadFile(filepath.Join(*dir, name))
+			if err != nil || !bytes.Equal(current, files[name]) {
+				stale = append(stale, name)
+			}
+		}
+		if len(stale) > 0 {
+			log.Fatalf("generated files are out of date: %s (run \"go generate\")", strings.Join(stale, ", "))
+		}
+		log.Println("Generated files are up to date")
+		return
+	}
 
-	type templateStruct struct {
-		Timestamp time.Time
-		Language  string
-		Phrases   []Phrase
+	for _, name := range names {
+		log.Printf("Writing %s", name)
+		err = os.WriteFile(filepath.Join(*dir, name), files[name], 0644)
+		die(err)
 	}
+}
 
-	log.Println("Generating golang files with phrases struct")
+// ReadLanguages reads every language folder inside dir.
+func ReadLanguages(dir string) ([]language, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
 
-	packageTemplate.Execute(fileDataBR, templateStruct{
-		Timestamp: time.Now(),
-		Language:  "PhrasesBR",
-		Phrases:   phrasesBR,
-	})
+	var languages []language
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		code := entry.Name()
+		if !languageCode.MatchString(code) {
+			log.Printf("Ignoring folder %q: not a language code", code)
+			continue
+		}
+
+		log.Printf("Converting %s phrases", strings.ToUpper(code))
+		phrases, err := ReadPhrasesFromDirectory(filepath.Join(dir, code))
+		if err != nil {
+			return nil, err
+		}
+		if len(phrases) == 0 {
+			return nil, fmt.Errorf("%s: no phrases found", filepath.Join(dir, code))
+		}
+
+		languages = append(languages, language{
+			Code:     code,
+			Variable: "Phrases" + strings.ToUpper(code),
+			Phrases:  phrases,
+		})
+	}
 
-	packageTemplate.Execute(fileDataUS, templateStruct{
-		Timestamp: time.Now(),
-		Language:  "PhrasesUS",
-		Phrases:   phrasesUS,
+	if len(languages) == 0 {
+		return nil, fmt.Errorf("%s: no language folders found", dir)
+	}
+
+	sort.Slice(languages, func(i, j int) bool {
+		return languages[i].Code < languages[j].Code
 	})
+	return languages, nil
 }
 
-func ReadPhrasesFromDirectory(path string) []Phrase {
-	entries, err := os.ReadDir(path)
+// ReadPhrasesFromDirectory reads, validates and dedups the phrases of all JSON
+// files in path. Files are read in lexical order.
+func ReadPhrasesFromDirectory(path string) ([]Phrase, error) {
+	files, err := filepath.Glob(filepath.Join(path, "*.json"))
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
+	sort.Strings(files)
 
 	phrases := []Phrase{}
+	seen := map[string]string{}
 
-	for _, file := range entries {
-		content, err := os.ReadFile(filepath.Join(path, file.Name()))
-		die(err)
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
 
-		p := []Phrase{}
-		json.Unmarshal(content, &p)
-		die(err)
+		p, err := decodePhrases(file, content)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, item := range p {
+			item.Quote = strings.TrimSpace(item.Quote)
+			item.Author = strings.TrimSpace(item.Author)
+
+			where := fmt.Sprintf("%s: phrase #%d", file, i+1)
+			if item.Quote == "" || item.Author == "" {
+				return nil, fmt.Errorf("%s: quote and author are required", where)
+			}
+			if !utf8.ValidString(item.Quote) || !utf8.ValidString(item.Author) {
+				return nil, fmt.Errorf("%s: invalid UTF-8", where)
+			}
+
+			if first, ok := seen[item.Quote]; ok {
+				log.Printf("Skipping %s: duplicate of %s", where, first)
+				continue
+			}
+			seen[item.Quote] = where
+
+			phrases = append(phrases, item)
+		}
+	}
+
+	return phrases, nil
+}
+
+// decodePhrases unmarshal the content of file, adding the line and column to
+// syntax and type errors.
+func decodePhrases(file string, content []byte) ([]Phrase, error) {
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+
+	var phrases []Phrase
+	err := decoder.Decode(&phrases)
+	if err == nil {
+		return phrases, nil
+	}
 
-		phrases = append(phrases, p...)
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		offset    int64 = -1
+	)
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
 	}
 
-	return phrases
+	if offset < 0 {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+
+	line, column := position(content, offset)
+	return nil, fmt.Errorf("%s:%d:%d: %w", file, line, column, err)
+}
+
+// position converts a byte offset to line and column, both starting at 1.
+func position(content []byte, offset int64) (line, column int) {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+
+	before := content[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = int(offset) - (bytes.LastIndexByte(before, '\n') + 1) + 1
+	return line, column
+}
+
+// Render returns the formatted content of each generated file by its name.
+func Render(languages []language) (map[string][]byte, error) {
+	files := map[string][]byte{}
+
+	for _, lang := range languages {
+		content, err := execute(phrasesTemplate, lang)
+		if err != nil {
+			return nil, fmt.Errorf("data_%s.go: %w", lang.Code, err)
+		}
+		files["data_"+lang.Code+".go"] = content
+	}
+
+	content, err := execute(languagesTemplate, languages)
+	if err != nil {
+		return nil, fmt.Errorf("languages.go: %w", err)
+	}
+	files["languages.go"] = content
+
+	return files, nil
+}
+
+func execute(t *template.Template, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+var funcs = template.FuncMap{
+	"quote": strconv.Quote,
 }
 
-var packageTemplate = template.Must(template.New("").Parse(`// Code generated by go generate; DO NOT EDIT.
-// This file was generated by robots at
-// {{ .Timestamp }}
+var phrasesTemplate = template.Must(template.New("phrases").Funcs(funcs).Parse(`// Code generated by go generate; DO NOT EDIT.
+
 package data
 
-var {{ .Language }} = []Phrase{
+// {{ .Variable }} embedded phrases of language "{{ .Code }}"
+var {{ .Variable }} = []Phrase{
 {{- range .Phrases }}
 	{
-		Phrase: "{{ .Quote }}",
-		Author: "{{ .Author }}",
+		Phrase:   {{ quote .Quote }},
+		Author:   {{ quote .Author }},
+		Language: {{ quote $.Code }},
 	},
 {{- end }}
 }
 `))
 
+var languagesTemplate = template.Must(template.New("languages").Funcs(funcs).Parse(`// Code generated by go generate; DO NOT EDIT.
+
+package data
+
+// Languages embedded phrases by language
+var Languages = map[string][]Phrase{
+{{- range . }}
+	{{ quote .Code }}: {{ .Variable }},
+{{- end }}
+}
+
+// LanguageCodes languages with embedded phrases, sorted
+var LanguageCodes = []string{
+{{- range . }}
+	{{ quote .Code }},
+{{- end }}
+}
+`))
+
 func die(err error) {
 	if err != nil {
 		log.Fatal(err)
@@ -1,407 +1,502 @@
 // Code generated by go generate; DO NOT EDIT.
-// This file was generated by robots at
-// 2025-05-11 22:11:56.3189485 -0300 -03 m=+0.003592401
+
 package data
 
+// PhrasesUS embedded phrases of language "us"
 var PhrasesUS = []Phrase{
 	{
-		Phrase: "Nothing is impossible, the word itself says “I’m possible”!",
-		Author: "Audrey Hepburn",
-	},
-	{
-		Phrase: "I’ve learned that people will forget what you said, people will forget what you did, but people will never forget how you made them feel.",
-		Author: "Maya Angelou",
+		Phrase:   "Nothing is impossible, the word itself says “I’m possible”!",
+		Author:   "Audrey Hepburn",
+		Language: "us",
 	},
 	{
-		Phrase: "Whether you think you can or you think you can’t, you’re right.",
-		Author: "Henry Ford",
+		Phrase:   "I’ve learned that people will forget what you said, people will forget what you did, but people will never forget how you made them feel.",
+		Author:   "Maya Angelou",
+		Language: "us",
 	},
 	{
-		Phrase: "Perfection is not attainable, but if we chase perfection we can catch excellence.",
-		Author: "Vince Lombardi",
+		Phrase:   "Whether you think you can or you think you can’t, you’re right.",
+		Author:   "Henry Ford",
+		Language: "us",
 	},
 	{
-		Phrase: "Life is 10 percent what happens to me and 90 percent of how I react to it.",
-		Author: "Charles Swindoll",
+		Phrase:   "Perfection is not attainable, but if we chase perfection we can catch excellence.",
+		Author:   "Vince Lombardi",
+		Language: "us",
 	},
 	{
-		Phrase: "If you look at what you have in life, you’ll always have more. If you look at what you don’t have in life, you’ll never have enough.",
-		Author: "Oprah Winfrey",
+		Phrase:   "Life is 10 percent what happens to me and 90 percent of how I react to it.",
+		Author:   "Charles Swindoll",
+		Language: "us",
 	},
 	{
-		Phrase: "None of us is as smart as all of us.",
-		Author: "Ken Blanchard",
+		Phrase:   "If you look at what you have in life, you’ll always have more. If you look at what you don’t have in life, you’ll never have enough.",
+		Author:   "Oprah Winfrey",
+		Language: "us",
 	},
 	{
-		Phrase: "I can’t change the direction of the wind, but I can adjust my sails to always reach my destination.",
-		Author: "Jimmy Dean",
+		Phrase:   "None of us is as smart as all of us.",
+		Author:   "Ken Blanchard",
+		Language: "us",
 	},
 	{
-		Phrase: "Believe you can and you’re halfway there.",
-		Author: "Theodore Roosevelt",
+		Phrase:   "I can’t change the direction of the wind, but I can adjust my sails to always reach my destination.",
+		Author:   "Jimmy Dean",
+		Language: "us",
 	},
 	{
-		Phrase: "To handle yourself, use your head; to handle others, use your heart.",
-		Author: "Eleanor Roosevelt",
+		Phrase:   "Believe you can and you’re halfway there.",
+		Author:   "Theodore Roosevelt",
+		Language: "us",
 	},
 	{
-		Phrase: "Too many of us are not living our dreams because we are living our fears.",
-		Author: "Les Brown",
+		Phrase:   "To handle yourself, use your head; to handle others, use your heart.",
+		Author:   "Eleanor Roosevelt",
+		Language: "us",
 	},
 	{
-		Phrase: "Alone, we can do so little; together we can do so much.",
-		Author: "Helen Keller",
+		Phrase:   "Too many of us are not living our dreams because we are living our fears.",
+		Author:   "Les Brown",
+		Language: "us",
 	},
 	{
-		Phrase: "Whatever the mind of man can conceive and believe, it can achieve.",
-		Author: "Napoleon Hill",
+		Phrase:   "Alone, we can do so little; together we can do so much.",
+		Author:   "Helen Keller",
+		Language: "us",
 	},
 	{
-		Phrase: "Twenty years from now you will be more disappointed by the things that you didn’t do than by the ones you did do, so throw off the bowlines, sail away from safe harbor, catch the trade winds in your sails. Explore, Dream, Discover.",
-		Author: "Mark Twain",
+		Phrase:   "Whatever the mind of man can conceive and believe, it can achieve.",
+		Author:   "Napoleon Hill",
+		Language: "us",
 	},
 	{
-		Phrase: "I’ve missed more than 9000 shots in my career. I’ve lost almost 300 games. 26 times I’ve been trusted to take the game winning shot and missed. I’ve failed over and over and over again in my life. And that is why I succeed.",
-		Author: "Michael Jordan",
+		Phrase:   "Twenty years from now you will be more disappointed by the things that you didn’t do than by the ones you did do, so throw off the bowlines, sail away from safe harbor, catch the trade winds in your sails. Explore, Dream, Discover.",
+		Author:   "Mark Twain",
+		Language: "us",
 	},
 	{
-		Phrase: "Strive not to be a success, but rather to be of value.",
-		Author: "Albert Einstein",
+		Phrase:   "I’ve missed more than 9000 shots in my career. I’ve lost almost 300 games. 26 times I’ve been trusted to take the game winning shot and missed. I’ve failed over and over and over again in my life. And that is why I succeed.",
+		Author:   "Michael Jordan",
+		Language: "us",
 	},
 	{
-		Phrase: "I am not a product of my circumstances. I am a product of my decisions.",
-		Author: "Stephen Covey",
+		Phrase:   "Strive not to be a success, but rather to be of value.",
+		Author:   "Albert Einstein",
+		Language: "us",
 	},
 	{
-		Phrase: "When everything seems to be going against you, remember that the airplane takes off against the wind, not with it.",
-		Author: "enry Ford",
+		Phrase:   "I am not a product of my circumstances. I am a product of my decisions.",
+		Author:   "Stephen Covey",
+		Language: "us",
 	},
 	{
-		Phrase: "The most common way people give up their power is by thinking they don’t have any.",
-		Author: "Alice Walker",
+		Phrase:   "When everything seems to be going against you, remember that the airplane takes off against the wind, not with it.",
+		Author:   "enry Ford",
+		Language: "us",
 	},
 	{
-		Phrase: "The most difficult thing is the decision to act, the rest is merely tenacity.",
-		Author: "Amelia Earhart",
+		Phrase:   "The most common way people give up their power is by thinking they don’t have any.",
+		Author:   "Alice Walker",
+		Language: "us",
 	},
 	{
-		Phrase: "Teamwork is the ability to work together toward a common vision, the ability to direct individual accomplishments toward organizational objectives. It is the fuel that allows common people to attain uncommon results.",
-		Author: "Andrew Carnegie",
+		Phrase:   "The most difficult thing is the decision to act, the rest is merely tenacity.",
+		Author:   "Amelia Earhart",
+		Language: "us",
 	},
 	{
-		Phrase: "Don’t judge each day by the harvest you reap but by the seeds that you plant.",
-		Author: "Robert Louis Stevenson",
+		Phrase:   "Teamwork is the ability to work together toward a common vision, the ability to direct individual accomplishments toward organizational objectives. It is the fuel that allows common people to attain uncommon results.",
+		Author:   "Andrew Carnegie",
+		Language: "us",
 	},
 	{
-		Phrase: "The real opportunity for success lies within the person and not in the job.",
-		Author: "Zig Ziglar",
+		Phrase:   "Don’t judge each day by the harvest you reap but by the seeds that you plant.",
+		Author:   "Robert Louis Stevenson",
+		Language: "us",
 	},
 	{
-		Phrase: "Change your thoughts and you change your world.",
-		Author: "Norman Vincent Peale",
+		Phrase:   "The real opportunity for success lies within the person and not in the job.",
+		Author:   "Zig Ziglar",
+		Language: "us",
 	},
 	{
-		Phrase: "There is no royal road to anything. One thing at a time, all things in succession. That which grows fast, withers as rapidly. That which grows slowly, endures.",
-		Author: "Josiah Gilbert Holland",
+		Phrase:   "Change your thoughts and you change your world.",
+		Author:   "Norman Vincent Peale",
+		Language: "us",
 	},
 	{
-		Phrase: "Be not afraid of life. Believe that life is worth living, and your belief will help create the fact.",
-		Author: "William James",
+		Phrase:   "There is no royal road to anything. One thing at a time, all things in succession. That which grows fast, withers as rapidly. That which grows slowly, endures.",
+		Author:   "Josiah Gilbert Holland",
+		Language: "us",
 	},
 	{
-		Phrase: "Build your own dreams, or someone else will hire you to build theirs.",
-		Author: "Farrah Gray",
+		Phrase:   "Be not afraid of life. Believe that life is worth living, and your belief will help create the fact.",
+		Author:   "William James",
+		Language: "us",
 	},
 	{
-		Phrase: "Remember that not getting what you want is sometimes a wonderful stroke of luck.",
-		Author: "Dalai Lama",
+		Phrase:   "Build your own dreams, or someone else will hire you to build theirs.",
+		Author:   "Farrah Gray",
+		Language: "us",
 	},
 	{
-		Phrase: "You can’t use up creativity. The more you use, the more you have.",
-		Author: "Maya Angelou",
+		Phrase:   "Remember that not getting what you want is sometimes a wonderful stroke of luck.",
+		Author:   "Dalai Lama",
+		Language: "us",
 	},
 	{
-		Phrase: "I have learned over the years that when one’s mind is made up, this diminishes fear.",
-		Author: "Rosa Parks",
+		Phrase:   "You can’t use up creativity. The more you use, the more you have.",
+		Author:   "Maya Angelou",
+		Language: "us",
 	},
 	{
-		Phrase: "I would rather die of passion than of boredom.",
-		Author: "Vincent van Gogh",
+		Phrase:   "I have learned over the years that when one’s mind is made up, this diminishes fear.",
+		Author:   "Rosa Parks",
+		Language: "us",
 	},
 	{
-		Phrase: "A truly rich man is one whose children run into his arms when his hands are empty.",
-		Author: "Unknown",
+		Phrase:   "I would rather die of passion than of boredom.",
+		Author:   "Vincent van Gogh",
+		Language: "us",
 	},
 	{
-		Phrase: "A person who never made a mistake never tried anything new.",
-		Author: "Albert Einstein",
+		Phrase:   "A truly rich man is one whose children run into his arms when his hands are empty.",
+		Author:   "Unknown",
+		Language: "us",
 	},
 	{
-		Phrase: "What’s money? A man is a success if he gets up in the morning and goes to bed at night and in between does what he wants to do.",
-		Author: "Bob Dylan",
+		Phrase:   "A person who never made a mistake never tried anything new.",
+		Author:   "Albert Einstein",
+		Language: "us",
 	},
 	{
-		Phrase: "I have been impressed with the urgency of doing. Knowing is not enough; we must apply. Being willing is not enough; we must do.",
-		Author: "Leonardo da Vinci",
+		Phrase:   "What’s money? A man is a success if he gets up in the morning and goes to bed at night and in between does what he wants to do.",
+		Author:   "Bob Dylan",
+		Language: "us",
 	},
 	{
-		Phrase: "If you want to lift yourself up, lift up someone else.",
-		Author: "Booker T. Washington",
+		Phrase:   "I have been impressed with the urgency of doing. Knowing is not enough; we must apply. Being willing is not enough; we must do.",
+		Author:   "Leonardo da Vinci",
+		Language: "us",
 	},
 	{
-		Phrase: "When I stand before God at the end of my life, I would hope that I would not have a single bit of talent left and could say, I used everything you gave me.",
-		Author: "Erma Bombeck",
+		Phrase:   "If you want to lift yourself up, lift up someone else.",
+		Author:   "Booker T. Washington",
+		Language: "us",
 	},
 	{
-		Phrase: "The quickest way to double your money is to fold it over and put it back in your pocket.",
-		Author: "Will Rogers",
+		Phrase:   "When I stand before God at the end of my life, I would hope that I would not have a single bit of talent left and could say, I used everything you gave me.",
+		Author:   "Erma Bombeck",
+		Language: "us",
 	},
 	{
-		Phrase: "Certain things catch your eye, but pursue only those that capture the heart.",
-		Author: "Ancient Indian Proverb",
+		Phrase:   "The quickest way to double your money is to fold it over and put it back in your pocket.",
+		Author:   "Will Rogers",
+		Language: "us",
 	},
 	{
-		Phrase: "When I hear somebody sigh, ‘Life is hard,’ I am always tempted to ask, ‘Compared to what?’”",
-		Author: "Sydney Harris",
+		Phrase:   "Certain things catch your eye, but pursue only those that capture the heart.",
+		Author:   "Ancient Indian Proverb",
+		Language: "us",
 	},
 	{
-		Phrase: "Everything has beauty, but not everyone can see.",
-		Author: "Confucius",
+		Phrase:   "When I hear somebody sigh, ‘Life is hard,’ I am always tempted to ask, ‘Compared to what?’”",
+		Author:   "Sydney Harris",
+		Language: "us",
 	},
 	{
-		Phrase: "Nurture your mind with great thoughts. To believe in the heroic makes heroes.",
-		Author: "Benjamin Disraeli",
+		Phrase:   "Everything has beauty, but not everyone can see.",
+		Author:   "Confucius",
+		Language: "us",
 	},
 	{
-		Phrase: "When I was 5 years old, my mother always told me that happiness was the key to life. When I went to school, they asked me what I wanted to be when I grew up. I wrote down “happy”. They told me I didn’t understand the assignment, and I told them they didn’t understand life.",
-		Author: "John Lennon",
+		Phrase:   "Nurture your mind with great thoughts. To believe in the heroic makes heroes.",
+		Author:   "Benjamin Disraeli",
+		Language: "us",
 	},
 	{
-		Phrase: "The only person you are destined to become is the person you decide to be.",
-		Author: "Ralph Waldo Emerson",
+		Phrase:   "When I was 5 years old, my mother always told me that happiness was the key to life. When I went to school, they asked me what I wanted to be when I grew up. I wrote down “happy”. They told me I didn’t understand the assignment, and I told them they didn’t understand life.",
+		Author:   "John Lennon",
+		Language: "us",
 	},
 	{
-		Phrase: "We can’t help everyone, but everyone can help someone.",
-		Author: "Ronald Reagan",
+		Phrase:   "The only person you are destined to become is the person you decide to be.",
+		Author:   "Ralph Waldo Emerson",
+		Language: "us",
 	},
 	{
-		Phrase: "Everything you’ve ever wanted is on the other side of fear.",
-		Author: "George Addair",
+		Phrase:   "We can’t help everyone, but everyone can help someone.",
+		Author:   "Ronald Reagan",
+		Language: "us",
 	},
 	{
-		Phrase: "We can easily forgive a child who is afraid of the dark; the real tragedy of life is when men are afraid of the light.",
-		Author: "Plato",
+		Phrase:   "Everything you’ve ever wanted is on the other side of fear.",
+		Author:   "George Addair",
+		Language: "us",
 	},
 	{
-		Phrase: "Nothing will work unless you do.",
-		Author: "Maya Angelou",
+		Phrase:   "We can easily forgive a child who is afraid of the dark; the real tragedy of life is when men are afraid of the light.",
+		Author:   "Plato",
+		Language: "us",
 	},
 	{
-		Phrase: "I alone cannot change the world, but I can cast a stone across the water to create many ripples.",
-		Author: "Mother Teresa",
+		Phrase:   "Nothing will work unless you do.",
+		Author:   "Maya Angelou",
+		Language: "us",
 	},
 	{
-		Phrase: "What we achieve inwardly will change outer reality.",
-		Author: "Plutarch",
+		Phrase:   "I alone cannot change the world, but I can cast a stone across the water to create many ripples.",
+		Author:   "Mother Teresa",
+		Language: "us",
 	},
 	{
-		Phrase: "There are two ways of spreading light: to be the candle or the mirror that reflects it.",
-		Author: "Edith Wharton",
+		Phrase:   "What we achieve inwardly will change outer reality.",
+		Author:   "Plutarch",
+		Language: "us",
 	},
 	{
-		Phrase: "You do not find the happy life. You make it.",
-		Author: "Camilla Eyring Kimball",
+		Phrase:   "There are two ways of spreading light: to be the candle or the mirror that reflects it.",
+		Author:   "Edith Wharton",
+		Language: "us",
 	},
 	{
-		Phrase: "The most wasted of days is one without laughter.",
-		Author: "E.E. Cummings",
+		Phrase:   "You do not find the happy life. You make it.",
+		Author:   "Camilla Eyring Kimball",
+		Language: "us",
 	},
 	{
-		Phrase: "Stay close to anything that makes you glad you are alive.",
-		Author: "Hafez",
+		Phrase:   "The most wasted of days is one without laughter.",
+		Author:   "E.E. Cummings",
+		Language: "us",
 	},
 	{
-		Phrase: "Make each day your masterpiece.",
-		Author: "John Wooden",
+		Phrase:   "Stay close to anything that makes you glad you are alive.",
+		Author:   "Hafez",
+		Language: "us",
 	},
 	{
-		Phrase: "Happiness often sneaks in through a door you didn’t know you left open.",
-		Author: "John Barrymore",
+		Phrase:   "Make each day your masterpiece.",
+		Author:   "John Wooden",
+		Language: "us",
 	},
 	{
-		Phrase: "Happiness is not by chance, but by choice.",
-		Author: "Jim Rohn",
+		Phrase:   "Happiness often sneaks in through a door you didn’t know you left open.",
+		Author:   "John Barrymore",
+		Language: "us",
 	},
 	{
-		Phrase: "Life changes very quickly, in a very positive way, if you let it.",
-		Author: "Lindsey Vonn",
+		Phrase:   "Happiness is not by chance, but by choice.",
+		Author:   "Jim Rohn",
+		Language: "us",
 	},
 	{
-		Phrase: "Keep your face to the sunshine and you cannot see a shadow.",
-		Author: "Helen Keller",
+		Phrase:   "Life changes very quickly, in a very positive way, if you let it.",
+		Author:   "Lindsey Vonn",
+		Language: "us",
 	},
 	{
-		Phrase: "Impossible is for the unwilling.",
-		Author: "John Keats",
+		Phrase:   "Keep your face to the sunshine and you cannot see a shadow.",
+		Author:   "Helen Keller",
+		Language: "us",
 	},
 	{
-		Phrase: "No pressure, no diamonds.",
-		Author: "Thomas Carlyle",
+		Phrase:   "Impossible is for the unwilling.",
+		Author:   "John Keats",
+		Language: "us",
 	},
 	{
-		Phrase: "Believe you can and you’re halfway there.",
-		Author: "Theodore Roosevelt",
+		Phrase:   "No pressure, no diamonds.",
+		Author:   "Thomas Carlyle",
+		Language: "us",
 	},
 	{
-		Phrase: "Failure is the condiment that gives success its flavor.",
-		Author: "Truman Capote",
+		Phrase:   "Failure is the condiment that gives success its flavor.",
+		Author:   "Truman Capote",
+		Language: "us",
 	},
 	{
-		Phrase: "It is never too late to be what you might have been.",
-		Author: "George Eliot",
+		Phrase:   "It is never too late to be what you might have been.",
+		Author:   "George Eliot",
+		Language: "us",
 	},
 	{
-		Phrase: "When you have a dream, you’ve got to grab it and never let go.",
-		Author: "Carol Burnett",
+		Phrase:   "When you have a dream, you’ve got to grab it and never let go.",
+		Author:   "Carol Burnett",
+		Language: "us",
 	},
 	{
-		Phrase: "You must be the change you wish to see in the world.",
-		Author: "Mahatma Gandhi",
+		Phrase:   "You must be the change you wish to see in the world.",
+		Author:   "Mahatma Gandhi",
+		Language: "us",
 	},
 	{
-		Phrase: "Stay foolish to stay sane.",
-		Author: "Maxime Lagacé",
+		Phrase:   "Stay foolish to stay sane.",
+		Author:   "Maxime Lagacé",
+		Language: "us",
 	},
 	{
-		Phrase: "Stay hungry. Stay foolish.",
-		Author: "Steve Jobs",
+		Phrase:   "Stay hungry. Stay foolish.",
+		Author:   "Steve Jobs",
+		Language: "us",
 	},
 	{
-		Phrase: "Whatever you are, be a good one.",
-		Author: "Abraham Lincoln ",
+		Phrase:   "Whatever you are, be a good one.",
+		Author:   "Abraham Lincoln",
+		Language: "us",
 	},
 	{
-		Phrase: "You must do the things you think you cannot do.",
-		Author: "Eleanor Roosevelt",
+		Phrase:   "You must do the things you think you cannot do.",
+		Author:   "Eleanor Roosevelt",
+		Language: "us",
 	},
 	{
-		Phrase: "Wherever you go, go with all your heart.",
-		Author: "Confucius",
+		Phrase:   "Wherever you go, go with all your heart.",
+		Author:   "Confucius",
+		Language: "us",
 	},
 	{
-		Phrase: "Be faithful to that which exists within yourself.",
-		Author: "André Gide",
+		Phrase:   "Be faithful to that which exists within yourself.",
+		Author:   "André Gide",
+		Language: "us",
 	},
 	{
-		Phrase: "Dream big and dare to fail.",
-		Author: "Norman Vaughan",
+		Phrase:   "Dream big and dare to fail.",
+		Author:   "Norman Vaughan",
+		Language: "us",
 	},
 	{
-		Phrase: "My mission in life is not merely to survive, but to thrive.",
-		Author: "Maya Angelou",
+		Phrase:   "My mission in life is not merely to survive, but to thrive.",
+		Author:   "Maya Angelou",
+		Language: "us",
 	},
 	{
-		Phrase: "You are enough just as you are.",
-		Author: "Meghan Markle",
+		Phrase:   "You are enough just as you are.",
+		Author:   "Meghan Markle",
+		Language: "us",
 	},
 	{
-		Phrase: "To be the best, you must be able to handle the worst.",
-		Author: "Wilson Kanadi",
+		Phrase:   "To be the best, you must be able to handle the worst.",
+		Author:   "Wilson Kanadi",
+		Language: "us",
 	},
 	{
-		Phrase: "No matter what you’re going through, there’s a light at the end of the tunnel.",
-		Author: "Demi Lovato",
+		Phrase:   "No matter what you’re going through, there’s a light at the end of the tunnel.",
+		Author:   "Demi Lovato",
+		Language: "us",
 	},
 	{
-		Phrase: "Life is like riding a bicycle. To keep your balance, you must keep moving.",
-		Author: "Albert Einstein",
+		Phrase:   "Life is like riding a bicycle. To keep your balance, you must keep moving.",
+		Author:   "Albert Einstein",
+		Language: "us",
 	},
 	{
-		Phrase: "Every moment is a fresh beginning.",
-		Author: "T.S. Eliot ",
+		Phrase:   "Every moment is a fresh beginning.",
+		Author:   "T.S. Eliot",
+		Language: "us",
 	},
 	{
-		Phrase: "No guts, no story.",
-		Author: "Chris Brady",
+		Phrase:   "No guts, no story.",
+		Author:   "Chris Brady",
+		Language: "us",
 	},
 	{
-		Phrase: "Keep going. Be all in.",
-		Author: "Bryan Hutchinson",
+		Phrase:   "Keep going. Be all in.",
+		Author:   "Bryan Hutchinson",
+		Language: "us",
 	},
 	{
-		Phrase: "Leave no stone unturned.",
-		Author: "Euripides",
+		Phrase:   "Leave no stone unturned.",
+		Author:   "Euripides",
+		Language: "us",
 	},
 	{
-		Phrase: "Nothing is impossible. The word itself says “I’m possible!”",
-		Author: "Audrey Hepburn",
+		Phrase:   "Nothing is impossible. The word itself says “I’m possible!”",
+		Author:   "Audrey Hepburn",
+		Language: "us",
 	},
 	{
-		Phrase: "It isn’t where you came from. It’s where you’re going that counts.",
-		Author: "Ella Fitzgerald",
+		Phrase:   "It isn’t where you came from. It’s where you’re going that counts.",
+		Author:   "Ella Fitzgerald",
+		Language: "us",
 	},
 	{
-		Phrase: "If it matters to you, you’ll find a way.",
-		Author: "Charlie Gilkey",
+		Phrase:   "If it matters to you, you’ll find a way.",
+		Author:   "Charlie Gilkey",
+		Language: "us",
 	},
 	{
-		Phrase: "Tough times never last, but tough people do.",
-		Author: "Dr. Robert Schuller",
+		Phrase:   "Tough times never last, but tough people do.",
+		Author:   "Dr. Robert Schuller",
+		Language: "us",
 	},
 	{
-		Phrase: "Turn your wounds into wisdom.",
-		Author: "Oprah Winfrey",
+		Phrase:   "Turn your wounds into wisdom.",
+		Author:   "Oprah Winfrey",
+		Language: "us",
 	},
 	{
-		Phrase: "The journey of a thousand miles begins with a single step.",
-		Author: "Lao Tzu",
+		Phrase:   "The journey of a thousand miles begins with a single step.",
+		Author:   "Lao Tzu",
+		Language: "us",
 	},
 	{
-		Phrase: "If you’re going through hell, keep going.",
-		Author: "Winston Churchill",
+		Phrase:   "If you’re going through hell, keep going.",
+		Author:   "Winston Churchill",
+		Language: "us",
 	},
 	{
-		Phrase: "Don’t wait, the time will never be just right.",
-		Author: "Napoleon Hill",
+		Phrase:   "Don’t wait, the time will never be just right.",
+		Author:   "Napoleon Hill",
+		Language: "us",
 	},
 	{
-		Phrase: "If I cannot do great things, I can do small things in a great way.",
-		Author: "Martin Luther King Jr.",
+		Phrase:   "If I cannot do great things, I can do small things in a great way.",
+		Author:   "Martin Luther King Jr.",
+		Language: "us",
 	},
 	{
-		Phrase: "Life is fragile. We’re not guaranteed a tomorrow so give it everything you’ve got.",
-		Author: "Tim Cook",
+		Phrase:   "Life is fragile. We’re not guaranteed a tomorrow so give it everything you’ve got.",
+		Author:   "Tim Cook",
+		Language: "us",
 	},
 	{
-		Phrase: "The bad news is time flies. The good news is you’re the pilot.",
-		Author: "Michael Altshuler",
+		Phrase:   "The bad news is time flies. The good news is you’re the pilot.",
+		Author:   "Michael Altshuler",
+		Language: "us",
 	},
 	{
-		Phrase: "Try to be a rainbow in someone’s cloud.",
-		Author: "Maya Angelou",
+		Phrase:   "Try to be a rainbow in someone’s cloud.",
+		Author:   "Maya Angelou",
+		Language: "us",
 	},
 	{
-		Phrase: "Some people look for a beautiful place. Others make a place beautiful.",
-		Author: "Hazrat Inayat Khan",
+		Phrase:   "Some people look for a beautiful place. Others make a place beautiful.",
+		Author:   "Hazrat Inayat Khan",
+		Language: "us",
 	},
 	{
-		Phrase: "Never let your sense of morals prevent you from doing what is right.",
-		Author: "Isaac Asimov",
+		Phrase:   "Never let your sense of morals prevent you from doing what is right.",
+		Author:   "Isaac Asimov",
+		Language: "us",
 	},
 	{
-		Phrase: "I’m starting to think this world is just a place for us to learn that we need each other more than we want to admit.",
-		Author: "Richelle E. Goodrich",
+		Phrase:   "I’m starting to think this world is just a place for us to learn that we need each other more than we want to admit.",
+		Author:   "Richelle E. Goodrich",
+		Language: "us",
 	},
 	{
-		Phrase: "We have a responsibility to help those around us and help others in need.",
-		Author: "Virginia Williams",
+		Phrase:   "We have a responsibility to help those around us and help others in need.",
+		Author:   "Virginia Williams",
+		Language: "us",
 	},
 	{
-		Phrase: "No one is useless in this world who lightens the burdens of others.",
-		Author: "Charles Dickens",
+		Phrase:   "No one is useless in this world who lightens the burdens of others.",
+		Author:   "Charles Dickens",
+		Language: "us",
 	},
 	{
-		Phrase: "No one has ever become poor by giving.",
-		Author: "Anne Frank",
+		Phrase:   "No one has ever become poor by giving.",
+		Author:   "Anne Frank",
+		Language: "us",
 	},
 }
@@ -1,6 +1,7 @@
-package main
+package motivar
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/sha1"
 	"database/sql"
@@ -8,14 +9,15 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
-	"github.com/mitchellh/go-homedir"
-	"github.com/wvoliveira/motivar/data"
-	"log/slog"
 	"math"
-	_ "modernc.org/sqlite"
+	"net/url"
 	"os"
-	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
+
+	_ "modernc.org/sqlite"
 )
 
 //go:embed all:migrations
@@ -27,62 +29,165 @@ type databasePhrase struct {
 	Phrase      string
 	PhraseHash  string
 	Language    string
-	CreateAt    time.Time
-	UpdateAt    time.Time
+	// Source is where the phrase was taken from, like a book title.
+	Source string
+	// TranslationOf is the text, or its hash, of the phrase this one is a
+	// translation of.
+	TranslationOf string `json:"translation_of"`
+	CreateAt      time.Time
+	UpdateAt      time.Time
 }
 
 type database struct {
 	conn *sql.DB
 }
 
-func (d *database) New() {
-	homeDir, err := homedir.Dir()
-	die(err)
+// busyTimeout is how long to wait for a lock held by another process, like
+// an import running while a new shell shows a phrase.
+const busyTimeout = 10 * time.Second
 
-	dbFile := path.Join(homeDir, ".motivar", "data", "database.db")
+// connParams of every connection. In WAL mode readers don't block the writer
+// and the writer doesn't block readers. Transactions take the write lock when
+// they begin, so they wait on busy_timeout instead of failing with "database
+// is locked" when upgrading a read lock.
+var connParams = url.Values{
+	"_pragma": {
+		fmt.Sprintf("busy_timeout(%d)", busyTimeout.Milliseconds()),
+		"journal_mode(WAL)",
+		"synchronous(NORMAL)",
+	},
+	"_txlock": {"immediate"},
+}
 
-	d.conn, err = sql.Open("sqlite", dbFile)
-	die(err)
-	return
+func openDatabase(dbFile string) (*database, error) {
+	err := os.MkdirAll(filepath.Dir(dbFile), 0764)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := sql.Open("sqlite", dbFile+"?"+connParams.Encode())
+	if err != nil {
+		return nil, err
+	}
+	return &database{conn: conn}, nil
 }
 
-func (d *database) ConnectAndTest() {
-	_, err := d.conn.Exec("SELECT 1;")
-	die(err)
+func (d *database) Close() error {
+	return d.conn.Close()
 }
 
-func (d *database) RunMigrations() {
+func (d *database) ConnectAndTest(ctx context.Context) error {
+	_, err := d.conn.ExecContext(ctx, "SELECT 1;")
+	return err
+}
+
+// migrations returns the files of the migrations folder in the order they
+// are applied.
+func migrations() ([]string, error) {
 	dir, err := embedContent.ReadDir("migrations")
 	if err != nil {
-		slog.Error(fmt.Sprintf("Error reading migrations folder: %v", err))
-		os.Exit(1)
+		return nil, fmt.Errorf("reading migrations folder: %w", err)
+	}
+	names := make([]string, len(dir))
+	for i, file := range dir {
+		names[i] = file.Name()
+	}
+	return sortMigrations(names)
+}
+
+// sortMigrations sorts the names of the migrations by their number, the
+// prefix before "-", and not as text, where 10 comes before 2. Numbers must
+// start at 0 without gaps, as the schema version is their count.
+func sortMigrations(names []string) ([]string, error) {
+	sorted := make([]string, len(names))
+	for _, name := range names {
+		prefix, _, _ := strings.Cut(name, "-")
+		n, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s doesn't start with its number", name)
+		}
+		if n < 0 || n >= len(names) || sorted[n] != "" {
+			return nil, fmt.Errorf("migration %s: numbers must go from 0 to %d without repeating", name, len(names)-1)
+		}
+		sorted[n] = name
+	}
+	return sorted, nil
+}
+
+// RunMigrations applies the migrations newer than the schema version of the
+// database. The schema version is the number of migrations applied. They run
+// in a transaction holding the write lock, so when many processes start at
+// same time only the first one applies them.
+func (d *database) RunMigrations(ctx context.Context) error {
+	files, err := migrations()
+	if err != nil {
+		return err
+	}
+
+	// Most of the time the schema is current, and reading the version doesn't
+	// need the write lock.
+	var version int
+	err = d.conn.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version)
+	if err != nil {
+		return err
+	}
+	if version >= len(files) {
+		return nil
+	}
+
+	tx, err := d.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Another process may have applied them while waiting for the lock.
+	err = tx.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version)
+	if err != nil {
+		return err
+	}
+	if version >= len(files) {
+		return nil
+	}
+
+	for _, name := range files[version:] {
+		sqlContent, err := embedContent.ReadFile("migrations/" + name)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, string(sqlContent))
+		if err != nil {
+			return fmt.Errorf("migration %s: %w", name, err)
+		}
 	}
-	for _, file := range dir {
-		sqlContent, err := embedContent.ReadFile("migrations/" + file.Name())
-		die(err)
 
-		_, err = d.conn.Exec(string(sqlContent))
-		die(err)
+	_, err = tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", len(files)))
+	if err != nil {
+		return err
 	}
+	return tx.Commit()
 }
 
-func (d *database) InsertPhrases(phrases []databasePhrase, url, contentHash string) (err error) {
+// InsertPhrases inserts phrases, imported from the content of url. verifiedKey
+// is the key that signed the content, or "".
+func (d *database) InsertPhrases(ctx context.Context, phrases []databasePhrase, url, contentHash, verifiedKey string) (inserted int, err error) {
 	if len(phrases) == 0 {
-		return errors.New("no phrases to insert")
+		return 0, errors.New("no phrases to insert")
 	}
 
-	tx, err := d.conn.Begin()
+	tx, err := d.conn.BeginTx(ctx, nil)
 	if err != nil {
 		return
 	}
 
 	defer tx.Rollback()
-	stHash, err := tx.Prepare("INSERT INTO hashes (id, url, content_hash, created_at, updated_at) VALUES (?, ?, ?, ?, ?)")
+	stHash, err := tx.PrepareContext(ctx, "INSERT INTO hashes (id, url, content_hash, verified_key, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?)")
 	if err != nil {
 		return
 	}
 
-	stPhrase, err := tx.Prepare("INSERT INTO phrases (id, author, phrase, phrase_hash, language, created_at, updated_at, hash_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT(phrase_hash) DO NOTHING")
+	stPhrase, err := tx.PrepareContext(ctx, "INSERT INTO phrases (id, author, phrase, phrase_hash, language, source, created_at, updated_at, hash_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT(phrase_hash) DO NOTHING")
 	if err != nil {
 		return
 	}
@@ -92,7 +197,7 @@ func (d *database) InsertPhrases(phrases []databasePhrase, url, contentHash stri
 
 	now := time.Now()
 	hashID := generateHashTimestamp()
-	_, err = stHash.Exec(hashID, url, contentHash, now, now)
+	_, err = stHash.ExecContext(ctx, hashID, url, contentHash, verifiedKey, now, now)
 	if err != nil {
 		return
 	}
@@ -100,29 +205,123 @@ func (d *database) InsertPhrases(phrases []databasePhrase, url, contentHash stri
 	for _, item := range phrases {
 		phraseID := generateHashTimestamp()
 
-		_, err = stPhrase.Exec(phraseID, item.Author, item.Phrase, item.PhraseHash, item.Language, now, now, hashID)
+		result, err := stPhrase.ExecContext(ctx, phraseID, item.Author, item.Phrase, item.PhraseHash, item.Language, item.Source, now, now, hashID)
+		if err != nil {
+			return 0, err
+		}
+
+		n, err := result.RowsAffected()
 		if err != nil {
-			return
+			return 0, err
 		}
+		inserted += int(n)
 	}
 
 	err = tx.Commit()
 	return
 }
 
-func (d *database) GetRandomPhrase(language string) (data.Phrase, error) {
-	row := d.conn.QueryRow("SELECT phrase, author FROM phrases WHERE language = ? ORDER BY RANDOM() LIMIT 1", language)
+// phraseColumns are selected to scan a Phrase.
+const phraseColumns = "id, phrase, author, language, source"
+
+func (d *database) GetRandomPhrase(ctx context.Context, language string, limits lengthLimits) (Phrase, error) {
+	conditions, args := limits.sql()
+	rows, err := d.conn.QueryContext(ctx, "SELECT "+phraseColumns+" FROM phrases WHERE language = ?"+conditions+" ORDER BY RANDOM()", append([]any{language}, args...)...)
+	if err != nil {
+		return Phrase{}, err
+	}
+	defer rows.Close()
+
+	// The first phrase fits, unless the limits are in words.
+	for rows.Next() {
+		var phrase Phrase
+		err = rows.Scan(&phrase.ID, &phrase.Phrase, &phrase.Author, &phrase.Language, &phrase.Source)
+		if err != nil {
+			return phrase, err
+		}
+		if limits.fits(phrase) {
+			return phrase, nil
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return Phrase{}, err
+	}
+	return Phrase{}, sql.ErrNoRows
+}
+
+func (d *database) GetPhrase(ctx context.Context, id int64) (Phrase, error) {
+	row := d.conn.QueryRowContext(ctx, "SELECT "+phraseColumns+" FROM phrases WHERE id = ?", id)
 
-	var phrase data.Phrase
-	err := row.Scan(&phrase.Phrase, &phrase.Author)
+	var phrase Phrase
+	err := row.Scan(&phrase.ID, &phrase.Phrase, &phrase.Author, &phrase.Language, &phrase.Source)
 	if err != nil {
 		return phrase, err
 	}
 	return phrase, nil
 }
 
-func (d *database) contentHashExists(hash string) (bool, error) {
-	row := d.conn.QueryRow("SELECT 1 FROM hashes WHERE content_hash = ? LIMIT 1", hash)
+// GetPhrases returns all phrases of language within limits ordered by
+// phrase hash.
+func (d *database) GetPhrases(ctx context.Context, language string, limits lengthLimits) ([]Phrase, error) {
+	conditions, args := limits.sql()
+	phrases, err := d.queryPhrases(ctx, "SELECT "+phraseColumns+" FROM phrases WHERE language = ?"+conditions+" ORDER BY phrase_hash", append([]any{language}, args...)...)
+	return limits.filter(phrases), err
+}
+
+// SearchPhrases returns phrases of language within limits whose text or
+// author contains query, ignoring case.
+func (d *database) SearchPhrases(ctx context.Context, language, query string, limits lengthLimits) ([]Phrase, error) {
+	like := "%" + escapeLike(strings.ToLower(query)) + "%"
+	conditions, args := limits.sql()
+	phrases, err := d.queryPhrases(ctx, "SELECT "+phraseColumns+` FROM phrases
+		WHERE language = ? AND (LOWER(phrase) LIKE ? ESCAPE '\' OR LOWER(author) LIKE ? ESCAPE '\')`+conditions+`
+		ORDER BY phrase_hash`, append([]any{language, like, like}, args...)...)
+	return limits.filter(phrases), err
+}
+
+func (d *database) queryPhrases(ctx context.Context, query string, args ...any) ([]Phrase, error) {
+	rows, err := d.conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var phrases []Phrase
+	for rows.Next() {
+		var phrase Phrase
+		err = rows.Scan(&phrase.ID, &phrase.Phrase, &phrase.Author, &phrase.Language, &phrase.Source)
+		if err != nil {
+			return nil, err
+		}
+		phrases = append(phrases, phrase)
+	}
+	return phrases, rows.Err()
+}
+
+// countPhrases returns how many phrases each language has.
+func (d *database) countPhrases(ctx context.Context) (map[string]int, error) {
+	rows, err := d.conn.QueryContext(ctx, "SELECT language, COUNT(*) FROM phrases GROUP BY language")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var (
+			language string
+			count    int
+		)
+		if err = rows.Scan(&language, &count); err != nil {
+			return nil, err
+		}
+		counts[language] = count
+	}
+	return counts, rows.Err()
+}
+
+func (d *database) contentHashExists(ctx context.Context, hash string) (bool, error) {
+	row := d.conn.QueryRowContext(ctx, "SELECT 1 FROM hashes WHERE content_hash = ? LIMIT 1", hash)
 
 	var temp int
 	err := row.Scan(&temp)
@@ -134,6 +333,10 @@ func (d *database) contentHashExists(hash string) (bool, error) {
 	return true, nil
 }
 
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
 func generateHashTimestamp() int64 {
 	timestamp := time.Now().UnixNano()
 
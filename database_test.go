@@ -0,0 +1,141 @@
+package motivar
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+const (
+	stressReaders = 8
+	stressReads   = 30
+	stressBatches = 20
+)
+
+// TestConcurrentProcesses starts readers and an importer as separate
+// processes using the same new database file, like many shells opening at
+// same time while add-phrases runs.
+func TestConcurrentProcesses(t *testing.T) {
+	if testing.Short() {
+		t.Skip("starts many processes")
+	}
+
+	dbFile := filepath.Join(t.TempDir(), "database.db")
+	roles := []string{"importer"}
+	for range stressReaders {
+		roles = append(roles, "reader")
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(roles))
+	for i, role := range roles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			cmd := exec.Command(os.Args[0], "-test.run=^TestConcurrentProcessesHelper$", "-test.v")
+			cmd.Env = append(os.Environ(),
+				"MOTIVAR_STRESS_ROLE="+role,
+				"MOTIVAR_STRESS_DB="+dbFile,
+				fmt.Sprintf("MOTIVAR_STRESS_ID=%d", i),
+			)
+			if out, err := cmd.CombinedOutput(); err != nil {
+				errs <- fmt.Errorf("%s %d: %v\n%s", role, i, err, out)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	client := newTestClient(t, WithDBPath(dbFile))
+	stats, err := client.Stats(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Phrases != stressBatches*10 {
+		t.Errorf("got %d phrases, want %d", stats.Phrases, stressBatches*10)
+	}
+}
+
+// TestConcurrentProcessesHelper is a process of TestConcurrentProcesses.
+func TestConcurrentProcessesHelper(t *testing.T) {
+	role := os.Getenv("MOTIVAR_STRESS_ROLE")
+	if role == "" {
+		t.Skip("only run by TestConcurrentProcesses")
+	}
+
+	ctx := context.Background()
+	client, err := New(WithDBPath(os.Getenv("MOTIVAR_STRESS_DB")))
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	defer client.Close()
+
+	db, err := client.database(ctx)
+	if err != nil {
+		t.Fatalf("opening database: %v", err)
+	}
+
+	switch role {
+	case "importer":
+		for batch := range stressBatches {
+			var phrases []databasePhrase
+			for i := range 10 {
+				text := fmt.Sprintf("Stress phrase %d of batch %d.", i, batch)
+				phrases = append(phrases, databasePhrase{
+					Author:     "Tester",
+					Phrase:     text,
+					PhraseHash: generateHash(text),
+					Language:   "us",
+				})
+			}
+
+			_, err = db.InsertPhrases(ctx, phrases, "stress", fmt.Sprintf("batch-%d", batch), "")
+			if err != nil {
+				t.Fatalf("InsertPhrases: %v", err)
+			}
+		}
+	case "reader":
+		for range stressReads {
+			if _, err = client.Random(ctx, Options{Language: "us"}); err != nil {
+				t.Fatalf("Random: %v", err)
+			}
+			if _, err = client.Search(ctx, "stress", Options{Language: "us"}); err != nil {
+				t.Fatalf("Search: %v", err)
+			}
+		}
+	}
+}
+
+func TestSortMigrations(t *testing.T) {
+	names, err := sortMigrations([]string{"0-a.sql", "1-b.sql", "10-k.sql", "2-c.sql", "3-d.sql", "4-e.sql", "5-f.sql", "6-g.sql", "7-h.sql", "8-i.sql", "9-j.sql"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names[2] != "2-c.sql" || names[10] != "10-k.sql" {
+		t.Errorf("got %v, want sorted by number", names)
+	}
+
+	for _, invalid := range [][]string{
+		{"0-a.sql", "2-c.sql"},
+		{"0-a.sql", "0-b.sql"},
+		{"0-a.sql", "b.sql"},
+	} {
+		if _, err := sortMigrations(invalid); err == nil {
+			t.Errorf("%v: want error", invalid)
+		}
+	}
+
+	// The embedded ones are valid.
+	if _, err := migrations(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,267 @@
+package motivar
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Near-duplicates are found in two steps. MinHash signatures of the character
+// shingles of each normalised phrase are split in bands, and phrases sharing
+// a band are candidates (locality-sensitive hashing). Then the similarity of
+// each candidate pair is the normalised edit distance of their texts.
+
+const (
+	shingleSize   = 3
+	minHashBands  = 20
+	minHashRows   = 4
+	minHashLength = minHashBands * minHashRows
+)
+
+// DuplicateGroup phrases similar to each other
+type DuplicateGroup struct {
+	// Phrases of the group. The first one is the suggested to keep: embedded
+	// phrases come before database phrases.
+	Phrases []Phrase
+	// Scores is the similarity, from 0 to 1, of each phrase with the first.
+	Scores []float64
+}
+
+// FindDuplicates returns the groups of phrases of opts language whose
+// similarity is at least threshold.
+func (c *Client) FindDuplicates(ctx context.Context, opts Options, threshold float64) ([]DuplicateGroup, error) {
+	if threshold <= 0 || threshold > 1 {
+		return nil, errors.New("threshold must be greater than 0 and at most 1")
+	}
+
+	phrases, err := c.all(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make([][]rune, len(phrases))
+	buckets := map[string][]int{}
+	for i, p := range phrases {
+		normalized[i] = []rune(normalizePhrase(p.Phrase))
+
+		signature := minHash(normalized[i])
+		for band := range minHashBands {
+			key := make([]byte, 0, 2+minHashRows*8)
+			key = binary.BigEndian.AppendUint16(key, uint16(band))
+			for _, v := range signature[band*minHashRows : (band+1)*minHashRows] {
+				key = binary.BigEndian.AppendUint64(key, v)
+			}
+			buckets[string(key)] = append(buckets[string(key)], i)
+		}
+	}
+
+	parent := make([]int, len(phrases))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	compared := map[[2]int]bool{}
+	for _, bucket := range buckets {
+		for x := 0; x < len(bucket); x++ {
+			for y := x + 1; y < len(bucket); y++ {
+				pair := [2]int{bucket[x], bucket[y]}
+				if compared[pair] {
+					continue
+				}
+				compared[pair] = true
+
+				if similarity(normalized[pair[0]], normalized[pair[1]]) >= threshold {
+					// Union keeping the lowest index as root, so embedded
+					// phrases stay first.
+					a, b := find(pair[0]), find(pair[1])
+					parent[max(a, b)] = min(a, b)
+				}
+			}
+		}
+	}
+
+	members := map[int][]int{}
+	for i := range phrases {
+		root := find(i)
+		members[root] = append(members[root], i)
+	}
+
+	var groups []DuplicateGroup
+	for root, indexes := range members {
+		if len(indexes) < 2 {
+			continue
+		}
+		sort.Ints(indexes)
+
+		group := DuplicateGroup{}
+		for _, i := range indexes {
+			group.Phrases = append(group.Phrases, phrases[i])
+			group.Scores = append(group.Scores, similarity(normalized[root], normalized[i]))
+		}
+		groups = append(groups, group)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Phrases[0].Phrase < groups[j].Phrases[0].Phrase
+	})
+	return groups, nil
+}
+
+// Merge removes the database phrases of remove, keeping keep. Translations
+// of the removed phrases are moved to keep. Embedded phrases can't be removed
+// and are ignored.
+func (c *Client) Merge(ctx context.Context, keep Phrase, remove []Phrase) (int, error) {
+	db, err := c.database(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	keepHash := generateHash(keep.Phrase)
+
+	var removed int
+	for _, p := range remove {
+		if p.ID == keep.ID || p.ID == embeddedID(p.Language, p.Phrase) {
+			continue
+		}
+
+		hash := generateHash(p.Phrase)
+		_, err = tx.ExecContext(ctx, "UPDATE OR IGNORE translations SET phrase_hash = ? WHERE phrase_hash = ?", keepHash, hash)
+		if err != nil {
+			return 0, err
+		}
+		_, err = tx.ExecContext(ctx, "DELETE FROM translations WHERE phrase_hash = ?", hash)
+		if err != nil {
+			return 0, err
+		}
+
+		result, err := tx.ExecContext(ctx, "DELETE FROM phrases WHERE id = ?", p.ID)
+		if err != nil {
+			return 0, fmt.Errorf("removing phrase %d: %w", p.ID, err)
+		}
+		n, _ := result.RowsAffected()
+		removed += int(n)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, err
+	}
+	c.invalidateCounts()
+	return removed, nil
+}
+
+var accents = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+	"’", "'", "‘", "'", "“", `"`, "”", `"`,
+)
+
+// normalizePhrase lower cases text, removes accents and punctuation, and
+// collapses spaces.
+func normalizePhrase(text string) string {
+	text = accents.Replace(strings.ToLower(text))
+
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// minHash returns the MinHash signature of the character shingles of text.
+func minHash(text []rune) [minHashLength]uint64 {
+	var signature [minHashLength]uint64
+	for i := range signature {
+		signature[i] = ^uint64(0)
+	}
+
+	if len(text) < shingleSize {
+		text = append(text, make([]rune, shingleSize-len(text))...)
+	}
+
+	for i := 0; i+shingleSize <= len(text); i++ {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(string(text[i : i+shingleSize])))
+		base := h.Sum64()
+
+		for j := range signature {
+			if v := mix(base + uint64(j)*0x9e3779b97f4a7c15); v < signature[j] {
+				signature[j] = v
+			}
+		}
+	}
+	return signature
+}
+
+// mix is the finalizer of SplitMix64.
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// similarity is 1 minus the edit distance of a and b divided by the length
+// of the longest one.
+func similarity(a, b []rune) float64 {
+	longest := max(len(a), len(b))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(a, b))/float64(longest)
+}
+
+// Levenshtein returns the edit distance between a and b, in runes: the
+// insertions, deletions and substitutions to turn a into b.
+func Levenshtein(a, b string) int {
+	return levenshtein([]rune(a), []rune(b))
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
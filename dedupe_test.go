@@ -0,0 +1,92 @@
+package motivar
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSimilarity(t *testing.T) {
+	pairs := []struct {
+		a, b string
+		min  float64
+		max  float64
+	}{
+		{"A persistência é o caminho do êxito.", "A persistencia e o caminho do exito", 1, 1},
+		{"Whatever you are, be a good one.", "Whatever you are be a good one", 1, 1},
+		{"O insucesso é apenas uma oportunidade para recomeçar.", "O insucesso é uma oportunidade para recomeçar.", 0.85, 0.95},
+		{"Whatever you are, be a good one.", "Success is not final, failure is not fatal.", 0, 0.5},
+	}
+
+	for _, p := range pairs {
+		got := similarity([]rune(normalizePhrase(p.a)), []rune(normalizePhrase(p.b)))
+		if got < p.min || got > p.max {
+			t.Errorf("similarity(%q, %q): got %.2f, want between %.2f and %.2f", p.a, p.b, got, p.min, p.max)
+		}
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "db", 2},
+		{"serve", "serve", 0},
+		{"sevre", "serve", 2},
+		{"tradução", "traduçao", 1},
+	}
+	for _, tt := range tests {
+		if got := Levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("Levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestFindDuplicatesAndMerge(t *testing.T) {
+	body := "author,phrase\n" +
+		"Anonymous,Small steps every day lead to great achievements!\n" +
+		"Anonymous,\"Small steps, every day, lead to great achievements.\"\n" +
+		"Anonymous,Small steps every day lead to great achievements\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	if _, err := client.Import(ctx, Source{URL: server.URL, Format: "csv", Language: "us"}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	groups, err := client.FindDuplicates(ctx, Options{Language: "us"}, 0.9)
+	if err != nil {
+		t.Fatalf("FindDuplicates: %v", err)
+	}
+
+	var group *DuplicateGroup
+	for i, g := range groups {
+		if g.Phrases[0].Author == "Anonymous" {
+			group = &groups[i]
+		}
+	}
+	if group == nil || len(group.Phrases) != 3 {
+		t.Fatalf("FindDuplicates: imported phrases not grouped: %+v", groups)
+	}
+
+	removed, err := client.Merge(ctx, group.Phrases[0], group.Phrases)
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Merge: got %d removed, want 2", removed)
+	}
+
+	found, _ := client.Search(ctx, "Small steps", Options{Language: "us"})
+	if len(found) != 1 {
+		t.Errorf("Search after Merge: got %d phrases, want 1", len(found))
+	}
+}
@@ -0,0 +1,192 @@
+package motivar
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"database/sql"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultFeedPatterns extract the phrase and the author of the items of RSS
+// and Atom feeds when Source.Patterns is empty. They are matched, in order,
+// against the title and the description of the item in two lines:
+//
+//	Quote of the day
+//	"Be yourself; everyone else is already taken." — Oscar Wilde
+var DefaultFeedPatterns = []string{
+	// The description is the phrase and the author.
+	`(?s)^[^\n]*\n(?P<phrase>.+?)\s*(?:—|–| -- | - )\s*(?P<author>[^\n—–]+)$`,
+	// The title is the phrase and the author.
+	`^(?P<phrase>[^\n]+?)\s*(?:—|–| -- | - )\s*(?P<author>[^\n—–]+)\n`,
+	// The title is the author and the description is the phrase.
+	`(?s)^(?P<author>[^\n]+)\n(?P<phrase>.+)$`,
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// feedDocument is a RSS 2.0 or Atom feed.
+type feedDocument struct {
+	XMLName xml.Name
+	// Items of RSS 2.0.
+	Items []struct {
+		Title       string `xml:"title"`
+		Description string `xml:"description"`
+		GUID        string `xml:"guid"`
+		Link        string `xml:"link"`
+	} `xml:"channel>item"`
+	// Entries of Atom.
+	Entries []struct {
+		Title   string `xml:"title"`
+		Summary string `xml:"summary"`
+		Content string `xml:"content"`
+		ID      string `xml:"id"`
+		Link    struct {
+			Href string `xml:"href,attr"`
+		} `xml:"link"`
+	} `xml:"entry"`
+}
+
+// compileFeedPatterns compiles patterns, or DefaultFeedPatterns if empty.
+func compileFeedPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	if len(patterns) == 0 {
+		patterns = DefaultFeedPatterns
+	}
+
+	var compiled []*regexp.Regexp
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid feed pattern %q: %w", p, err)
+		}
+		if re.SubexpIndex("phrase") < 0 || re.SubexpIndex("author") < 0 {
+			return nil, fmt.Errorf("feed pattern %q needs the groups (?P<phrase>...) and (?P<author>...)", p)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// parseFeed reads the items of a RSS 2.0 or Atom feed, in the feed order.
+// Items not matched by the patterns have no phrase, but keep their ID.
+func parseFeed(body []byte, opts parseOptions) ([]sourcePhrase, error) {
+	patterns := opts.feedPatterns
+	if patterns == nil {
+		var err error
+		if patterns, err = compileFeedPatterns(nil); err != nil {
+			return nil, err
+		}
+	}
+
+	var doc feedDocument
+	if err := xml.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("invalid feed format: %w", err)
+	}
+	if doc.XMLName.Local != "rss" && doc.XMLName.Local != "feed" {
+		return nil, fmt.Errorf("invalid feed format: root element %q isn't rss or feed", doc.XMLName.Local)
+	}
+
+	var phrases []sourcePhrase
+	add := func(id, title, description string) {
+		title, description = feedText(title), feedText(description)
+		if id == "" {
+			id = generateHash(title + "\n" + description)
+		}
+		p := matchFeedItem(patterns, title+"\n"+description)
+		p.ID = id
+		phrases = append(phrases, p)
+	}
+
+	for _, item := range doc.Items {
+		add(cmp.Or(item.GUID, item.Link), item.Title, item.Description)
+	}
+	for _, entry := range doc.Entries {
+		add(cmp.Or(entry.ID, entry.Link.Href), entry.Title, cmp.Or(entry.Summary, entry.Content))
+	}
+	return phrases, nil
+}
+
+// matchFeedItem returns the phrase and author of the first pattern that
+// matches text.
+func matchFeedItem(patterns []*regexp.Regexp, text string) sourcePhrase {
+	for _, re := range patterns {
+		m := re.FindStringSubmatch(text)
+		if m == nil {
+			continue
+		}
+		return sourcePhrase{
+			Author: strings.TrimSpace(m[re.SubexpIndex("author")]),
+			Phrase: trimQuotes(m[re.SubexpIndex("phrase")]),
+		}
+	}
+	return sourcePhrase{}
+}
+
+// feedText returns the text of s, that may have HTML, in one line.
+func feedText(s string) string {
+	s = html.UnescapeString(htmlTag.ReplaceAllString(s, " "))
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func sniffFeed(body []byte) bool {
+	head := body[:min(len(body), 1024)]
+	return bytes.Contains(head, []byte("<rss")) || bytes.Contains(head, []byte("http://www.w3.org/2005/Atom"))
+}
+
+// saveFeed saves the items of the feed at url that are newer than the last
+// item seen in the previous import. Feeds have the newest items first.
+func (c *Client) saveFeed(ctx context.Context, db *database, r req, items []sourcePhrase, url, language string) (int, error) {
+	if len(items) == 0 {
+		return 0, errors.New("no items in the feed")
+	}
+
+	last, err := db.lastFeedItem(ctx, url)
+	if err != nil {
+		return 0, err
+	}
+	newItems := items
+	if i := slices.IndexFunc(items, func(p sourcePhrase) bool { return p.ID == last }); i >= 0 {
+		newItems = items[:i]
+	}
+	if len(newItems) == 0 {
+		c.logger.Info("No new items in the feed.")
+		return 0, nil
+	}
+	c.logger.Info(fmt.Sprintf("%d new items in the feed", len(newItems)))
+
+	// New items without phrases to insert are seen too, or every import
+	// would download and reject them again.
+	inserted, err := c.save(ctx, db, r, newItems, url, language)
+	if errors.Is(err, errNoPhrases) || errors.Is(err, errAllBlocked) {
+		c.logger.Info(fmt.Sprintf("No phrases in the new items of the feed: %v.", err))
+		err = nil
+	}
+	if err != nil {
+		return inserted, err
+	}
+	return inserted, db.setLastFeedItem(ctx, url, items[0].ID)
+}
+
+// lastFeedItem returns the ID of the newest item of the feed at url in the
+// previous import, or "" if it wasn't imported.
+func (d *database) lastFeedItem(ctx context.Context, url string) (string, error) {
+	var id string
+	err := d.conn.QueryRowContext(ctx, "SELECT last_item FROM feeds WHERE url = ?", url).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return id, err
+}
+
+func (d *database) setLastFeedItem(ctx context.Context, url, id string) error {
+	_, err := d.conn.ExecContext(ctx, `INSERT INTO feeds (url, last_item, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT(url) DO UPDATE SET last_item = excluded.last_item, updated_at = excluded.updated_at`, url, id, time.Now())
+	return err
+}
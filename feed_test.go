@@ -0,0 +1,155 @@
+package motivar
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseFeed(t *testing.T) {
+	tests := map[string][]string{
+		"rss":  {"https://example.com/quotes/3", "quote-2", "https://example.com/quotes/1"},
+		"atom": {"urn:quote:3", "urn:quote:2", "https://example.com/quotes/1"},
+	}
+	for name, ids := range tests {
+		t.Run(name, func(t *testing.T) {
+			body, err := os.ReadFile("samples/feed." + name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			items, err := parseFeed(body, parseOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != len(samplePhrases) {
+				t.Fatalf("got %d items, want %d", len(items), len(samplePhrases))
+			}
+			for i, item := range items {
+				want := samplePhrases[i]
+				want.ID = ids[i]
+				if item != want {
+					t.Errorf("item %d:\ngot  %q\nwant %q", i, item, want)
+				}
+			}
+
+			if f := detectFormat("http://example.com/feed", body); f == nil || f.name != "rss" {
+				t.Errorf("detectFormat: got %v, want rss", f)
+			}
+		})
+	}
+}
+
+func TestFeedPatterns(t *testing.T) {
+	body := []byte(`<rss version="2.0"><channel>
+		<item><title>Daily quote: Be yourself. (Oscar Wilde)</title><guid>1</guid></item>
+		<item><title>Not a quote</title><guid>2</guid></item>
+	</channel></rss>`)
+
+	patterns, err := compileFeedPatterns([]string{`^Daily quote: (?P<phrase>.+) \((?P<author>[^)]+)\)`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	items, err := parseFeed(body, parseOptions{feedPatterns: patterns})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []sourcePhrase{{Author: "Oscar Wilde", Phrase: "Be yourself.", ID: "1"}, {ID: "2"}}
+	if len(items) != 2 || items[0] != want[0] || items[1] != want[1] {
+		t.Errorf("got %q, want %q", items, want)
+	}
+
+	for _, p := range []string{`(?P<phrase>.+`, `(?P<phrase>.+) - (.+)`} {
+		if _, err := compileFeedPatterns([]string{p}); err == nil {
+			t.Errorf("compileFeedPatterns(%q): want error", p)
+		}
+	}
+}
+
+func TestImportFeed(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		feed []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = w.Write(feed)
+	}))
+	defer server.Close()
+
+	setFeed := func(body string) {
+		mu.Lock()
+		defer mu.Unlock()
+		feed = []byte(body)
+	}
+	item := func(guid, title, description string) string {
+		return "<item><guid>" + guid + "</guid><title>" + title + "</title><description>" + description + "</description></item>"
+	}
+	rss := func(items ...string) string {
+		return `<rss version="2.0"><channel><title>Quotes</title>` + strings.Join(items, "") + `</channel></rss>`
+	}
+
+	ctx := context.Background()
+	client := newTestClient(t, WithHTTPClient(server.Client()))
+	src := Source{URL: server.URL + "/feed", Format: "rss", Language: "us"}
+
+	setFeed(rss(
+		item("2", "Eleanor Roosevelt", "The future belongs to those who believe in the beauty of their dreams."),
+		item("1", "Lao Tzu", "The journey of a thousand miles begins with one step."),
+	))
+	if inserted, err := client.Import(ctx, src); err != nil || inserted != 2 {
+		t.Fatalf("first Import: got %d, %v; want 2 phrases", inserted, err)
+	}
+
+	// The same feed has no new items.
+	if inserted, err := client.Import(ctx, src); err != nil || inserted != 0 {
+		t.Errorf("same feed: got %d, %v; want 0 phrases", inserted, err)
+	}
+
+	// Only the items before the last seen one are new, even if the old ones
+	// changed.
+	setFeed(rss(
+		item("3", "Walt Disney", "The way to get started is to quit talking and begin doing."),
+		item("2", "Eleanor Roosevelt", "The future belongs to those who believe in the beauty of their dreams!"),
+		item("1", "Lao Tzu", "The journey of a thousand miles begins with one step."),
+	))
+	if inserted, err := client.Import(ctx, src); err != nil || inserted != 1 {
+		t.Errorf("new item: got %d, %v; want 1 phrase", inserted, err)
+	}
+
+	found, err := client.Search(ctx, "beauty of their dreams", Options{Language: "us"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 {
+		t.Errorf("changed item was imported again: %+v", found)
+	}
+
+	// Items without phrases are seen, and not an error.
+	setFeed(rss(
+		item("4", "", "A description without author."),
+		item("3", "Walt Disney", "The way to get started is to quit talking and begin doing."),
+	))
+	if inserted, err := client.Import(ctx, src); err != nil || inserted != 0 {
+		t.Errorf("item without phrase: got %d, %v; want 0 phrases", inserted, err)
+	}
+	db, err := client.database(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last, err := db.lastFeedItem(ctx, src.URL); err != nil || last != "4" {
+		t.Errorf("last item: got %q, %v; want 4", last, err)
+	}
+
+	_, err = client.Import(ctx, Source{URL: src.URL, Format: "rss", Language: "us", Patterns: []string{"("}})
+	if err == nil || errors.Is(err, ErrContentExists) {
+		t.Errorf("invalid pattern: got %v", err)
+	}
+}
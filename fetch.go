@@ -1,17 +1,20 @@
-package main
+package motivar
 
 import (
-	"bytes"
+	"context"
 	"crypto/sha256"
-	"encoding/csv"
 	"encoding/hex"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/wvoliveira/motivar/data"
+	"io"
 	"log/slog"
 	"net/http"
 	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/wvoliveira/motivar/langdetect"
 )
 
 // Get phrases from internet.
@@ -19,7 +22,7 @@ import (
 // - CSV: https://gist.githubusercontent.com/JakubPetriska/060958fd744ca34f099e947cd080b540/raw/963b5a9355f04741239407320ac973a6096cd7b6/quotes.csv
 // - JSON: https://raw.githubusercontent.com/AtaGowani/daily-motivation/refs/heads/master/src/data/quotes.json
 //
-// Supported file types: csv and json.
+// Supported file types are in formats.go.
 // Use files in the samples folder to develop some fetch feature.
 //
 // TODO:
@@ -28,245 +31,285 @@ import (
 
 const BodyMaxLength = 200000
 
+// minBatchConfidence is the confidence of the language detection needed to
+// count a phrase as of another language.
+const minBatchConfidence = 0.2
+
+// ErrContentExists is returned by Import when the same content was already
+// imported.
+var ErrContentExists = errors.New("this content already exists in the database")
+
+// Errors of content without phrases to insert.
+var (
+	errNoPhrases  = errors.New("no phrases found in the content")
+	errAllBlocked = errors.New("all phrases of the content were blocked by the content filter")
+)
+
 type req struct {
-	Body           []byte
-	BodyHash       string
-	CSVContent     [][]string
-	Phrases        []data.Phrase
-	DatabaseObject []databasePhrase
+	logger   *slog.Logger
+	Body     []byte
+	BodyHash string
+	// VerifiedKey signed the content, see Client.verify.
+	VerifiedKey string
 }
 
-func fetchAndSave(db *database, kind, url, language string) (err error) {
-	if kind == "" || url == "" || language == "" {
-		return errors.New("kind, url or language is empty")
+func (c *Client) fetchAndSave(ctx context.Context, src Source) (inserted int, err error) {
+	if src.Format == "" || src.URL == "" || src.Language == "" {
+		return 0, errors.New("format, url or language is empty")
 	}
 
-	var r req
+	db, err := c.database(ctx)
+	if err != nil {
+		return 0, err
+	}
 
-	switch kind {
-	case "csv":
-		logg.Info(fmt.Sprintf("Fetching %s", url))
-		content, contentHash, err := fetch(url)
-		if err != nil {
-			return err
-		}
-		logg.Debug(fmt.Sprintf("Hash of content: %s", contentHash))
-		r.Body = content
-		r.BodyHash = contentHash
+	c.logger.Info(fmt.Sprintf("Fetching %s", src.URL))
+	content, contentHash, err := c.fetch(ctx, src.URL)
+	if err != nil {
+		return 0, err
+	}
+	c.logger.Debug(fmt.Sprintf("Hash of content: %s", contentHash))
 
-		logg.Info("Validating content format...")
-		if !r.IsCSV() {
-			return errors.New("invalid CSV format")
-		}
+	verifiedKey, err := c.verify(ctx, src, content, contentHash)
+	if err != nil {
+		return 0, err
+	}
 
-		logg.Info("Trying to convert bytes to CSV format...")
-		csvLines, err := r.ConvertToCSV()
-		if err != nil {
-			return err
-		}
+	var u unpacker
+	files, err := u.unpack(path.Base(strings.SplitN(src.URL, "?", 2)[0]), content)
+	if err != nil {
+		return 0, err
+	}
 
-		r.CSVContent = csvLines
+	patterns, err := compileFeedPatterns(src.Patterns)
+	if err != nil {
+		return 0, err
+	}
 
-		logg.Info("Checking if hash content exists in database.")
-		exists, err := db.contentHashExists(r.BodyHash)
-		if err != nil {
-			return err
+	if len(files) == 1 && !files[0].archived {
+		f := lookupFormat(src.Format)
+		if src.Format == FormatAuto {
+			f = detectFormat(files[0].name, files[0].body)
+			if f == nil {
+				return 0, errors.New("format of the content not detected. Use -fmt")
+			}
+			c.logger.Info(fmt.Sprintf("Detected format %s", f.name))
 		}
+		return c.saveFile(ctx, db, src, f, files[0], src.URL, patterns, verifiedKey)
+	}
 
-		if exists {
-			logg.Warn("This content already exists in the database. Exiting...")
-			os.Exit(1)
+	// Every file of the archive with the extension of the format, or of any
+	// format with FormatAuto, is saved with its own hash.
+	var saved, existing int
+	for _, file := range files {
+		f := formatByExtension(file.name, file.body)
+		if f == nil || (src.Format != FormatAuto && f.name != src.Format) {
+			c.logger.Debug(fmt.Sprintf("Skipping %s of the archive", file.name))
+			continue
 		}
 
-		logg.Info("Parse CSV content to database object.")
-		databasePhrases, err := r.CSVToDatabaseObject(language)
-		if err != nil {
-			return err
+		c.logger.Info(fmt.Sprintf("Importing %s of the archive as %s", file.name, f.name))
+		n, err := c.saveFile(ctx, db, src, f, file, src.URL+"#"+file.name, patterns, verifiedKey)
+		if errors.Is(err, ErrContentExists) {
+			c.logger.Info(fmt.Sprintf("%s was already imported", file.name))
+			existing++
+			continue
 		}
-		r.DatabaseObject = databasePhrases
-
-		logg.Info("Inserting in the database...")
-		err = db.InsertPhrases(r.DatabaseObject, url, r.BodyHash)
 		if err != nil {
-			return err
+			return inserted, fmt.Errorf("%s: %w", file.name, err)
 		}
+		inserted += n
+		saved++
+	}
 
-		logg.Info("OK, phrases into database.")
-	case "json":
-		logg.Info(fmt.Sprintf("Fetching %s", url))
-		content, contentHash, err := fetch(url)
-		if err != nil {
-			return err
-		}
-		logg.Debug(fmt.Sprintf("Hash of content: %s", contentHash))
+	switch {
+	case saved == 0 && existing == 0:
+		return 0, errors.New("no files of a supported format in the archive")
+	case saved == 0:
+		return 0, ErrContentExists
+	}
+	return inserted, nil
+}
 
-		r.Body = content
-		r.BodyHash = contentHash
+// saveFile parses file in format f and saves its phrases as the content of
+// url, signed by verifiedKey.
+func (c *Client) saveFile(ctx context.Context, db *database, src Source, f *format, file sourceFile, url string, patterns []*regexp.Regexp, verifiedKey string) (int, error) {
+	r := req{logger: c.logger, Body: file.body, BodyHash: generateHash(string(file.body)), VerifiedKey: verifiedKey}
 
-		logg.Info("Validating content format...")
-		if !r.IsJSON() {
-			return errors.New("invalid JSON format")
-		}
+	c.logger.Info("Validating content format...")
+	items, err := f.parse(file.body, parseOptions{authorSeparator: src.AuthorSeparator, feedPatterns: patterns})
+	if err != nil {
+		return 0, err
+	}
+	if f.feed {
+		return c.saveFeed(ctx, db, r, items, url, src.Language)
+	}
+	return c.save(ctx, db, r, items, url, src.Language)
+}
 
-		logg.Info("Checking if hash content exists in database.")
-		exists, err := db.contentHashExists(r.BodyHash)
-		if err != nil {
-			return err
-		}
+// save inserts the phrases parsed from the content of url, unless the
+// content was imported before.
+func (c *Client) save(ctx context.Context, db *database, r req, items []sourcePhrase, url, language string) (inserted int, err error) {
+	c.logger.Info("Checking if hash content exists in database.")
+	exists, err := db.contentHashExists(ctx, r.BodyHash)
+	if err != nil {
+		return 0, err
+	}
+	if exists {
+		return 0, ErrContentExists
+	}
 
-		if exists {
-			logg.Warn("This content already exists in the database. Bye.")
-			os.Exit(1)
+	phrases := r.toDatabasePhrases(items, language)
+	if len(phrases) == 0 {
+		return 0, errNoPhrases
+	}
+	if c.filter != nil {
+		phrases = c.safeToImport(phrases)
+		if len(phrases) == 0 {
+			return 0, errAllBlocked
 		}
+	}
+	c.checkBatchLanguage(phrases, language)
 
-		logg.Info("Trying to convert to database Object.")
-		databasePhrases, err := r.JSONToDatabaseObject(language)
-		if err != nil {
-			return err
-		}
-		r.DatabaseObject = databasePhrases
+	c.logger.Info("Inserting in the database...")
+	inserted, err = db.InsertPhrases(ctx, phrases, url, r.BodyHash, r.VerifiedKey)
+	if err != nil {
+		return 0, err
+	}
+	c.invalidateCounts()
 
-		logg.Info("Inserting in the database...")
-		err = db.InsertPhrases(r.DatabaseObject, url, r.BodyHash)
+	err = c.linkImported(ctx, phrases)
+	if err != nil {
+		return inserted, err
+	}
+
+	c.logger.Info(fmt.Sprintf("OK, %d phrases into database.", inserted))
+	return inserted, nil
+}
+
+// fetch returns the content at url and its hash. A url without scheme, or
+// with file://, is a local file.
+func (c *Client) fetch(ctx context.Context, url string) ([]byte, string, error) {
+	if path, ok := localPath(url); ok {
+		file, err := os.Open(path)
 		if err != nil {
-			return err
+			return nil, "", err
 		}
+		defer file.Close()
+		return readBody(file)
+	}
 
-		logg.Info("OK, phrases into database.")
-	default:
-		return errors.New("unknown kind")
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
 	}
-	return nil
-}
 
-func fetch(url string) ([]byte, string, error) {
-	resp, err := http.Get(url)
-	die(err)
+	resp, err := c.httpClient.Do(request)
+	if err != nil {
+		return nil, "", err
+	}
 
 	defer resp.Body.Close()
 
-	if resp.StatusCode != 200 {
-		slog.Error("Fetching %s: %d", url, resp.StatusCode)
-		os.Exit(1)
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("fetching %s: %s", url, resp.Status)
 	}
 
-	// Read 100 bytes for each loop to prevent buffer overflow.
+	return readBody(resp.Body)
+}
+
+// readBody reads the content up to BodyMaxLength and hashes it.
+func readBody(r io.Reader) ([]byte, string, error) {
 	// Max 200000 bytes == 200 KB. It's enough to process 2k lines with csv format.
-	buf := make([]byte, 100)
-	var (
-		body   []byte
-		n      int
-		length int
-	)
-
-	for err == nil {
-		n, err = resp.Body.Read(buf)
-		body = append(body, buf[:n]...)
-		length += n
-
-		if length > BodyMaxLength {
-			slog.Error(fmt.Sprintf("the body (%v) exceeded the limit (%v)", length, BodyMaxLength))
-			os.Exit(1)
-		}
+	// Read one byte more than the limit to know if the body exceeded it.
+	body, err := io.ReadAll(io.LimitReader(r, BodyMaxLength+1))
+	if err != nil {
+		return nil, "", err
 	}
-	contentHash := generateHash(string(body))
 
-	// TODO:
-	// - Check if this hash already in the database.
-	// - Check if phrase hash exists in the database.
+	if len(body) > BodyMaxLength {
+		return nil, "", fmt.Errorf("the body exceeded the limit (%v)", BodyMaxLength)
+	}
+	contentHash := generateHash(string(body))
 
 	return body, contentHash, nil
 }
 
-func (r req) IsCSV() bool {
-	reader := csv.NewReader(bytes.NewReader(r.Body))
-	_, err := reader.ReadAll()
-	return err == nil
-}
-
-func (r req) IsJSON() bool {
-	var temp []map[string]interface{}
-	return json.Unmarshal(r.Body, &temp) == nil
-}
-
-func (r req) ConvertToCSV() (csvLines [][]string, err error) {
-	reader := csv.NewReader(bytes.NewReader(r.Body))
-	csvLines, err = reader.ReadAll()
-	if err != nil {
-		return [][]string{}, err
+// localPath returns the path of url if it's a local file.
+func localPath(url string) (string, bool) {
+	if path, ok := strings.CutPrefix(url, "file://"); ok {
+		return path, true
 	}
-	return csvLines, nil
+	return url, !strings.Contains(url, "://")
 }
 
-func convertFromJSON(body []byte, object *[]data.Phrase) (err error) {
-	return json.Unmarshal(body, &object)
-}
+// toDatabasePhrases validates the phrases parsed from the content and sets
+// their language and hashes.
+func (r req) toDatabasePhrases(items []sourcePhrase, language string) []databasePhrase {
+	var dbPhrases []databasePhrase
+	for _, item := range items {
+		item.Author = strings.TrimSpace(item.Author)
+		item.Phrase = strings.TrimSpace(item.Phrase)
 
-func (r req) CSVToDatabaseObject(language string) (dbPhrases []databasePhrase, err error) {
-	if language == "" {
-		return dbPhrases, errors.New("url or language is empty")
-	}
-
-	// Here we jump the first line to not process the headers.
-	// Maybe a flag or describe in help message to warn the final user?
-	for _, line := range r.CSVContent[1:] {
-		// In the CSV content, each line needs to have length of two.
-		// First is author and second is phrase.
-		if len(line) != 2 {
+		// Don't input in database if author or phrase is empty.
+		if item.Phrase == "" || item.Author == "" {
+			r.logger.Debug(fmt.Sprintf("Author or phrase is empty: author=\"%s\" phrase=\"%s\"", item.Author, item.Phrase))
 			continue
 		}
 
-		// Don't input in database if author or phrase is empty.
-		if line[0] == "" || line[1] == "" {
-			slog.Debug(fmt.Sprintf("Author or phrase is empty: author=\"%s\" phrase=\"%s\"", line[0], line[1]))
+		lang, ok := r.phraseLanguage(language, item.Phrase)
+		if !ok {
 			continue
 		}
 
-		phraseHash := generateHash(line[1])
-
-		phrase := databasePhrase{
-			ContentHash: r.BodyHash,
-			Author:      line[0],
-			Phrase:      line[1],
-			PhraseHash:  phraseHash,
-			Language:    language,
-		}
-		dbPhrases = append(dbPhrases, phrase)
+		dbPhrases = append(dbPhrases, databasePhrase{
+			ContentHash:   r.BodyHash,
+			Author:        item.Author,
+			Phrase:        item.Phrase,
+			PhraseHash:    generateHash(item.Phrase),
+			Language:      lang,
+			Source:        strings.TrimSpace(item.Source),
+			TranslationOf: item.TranslationOf,
+		})
 	}
-	return dbPhrases, nil
+	return dbPhrases
 }
 
-func (r req) JSONToDatabaseObject(language string) (dbPhrases []databasePhrase, err error) {
-	if language == "" {
-		return dbPhrases, errors.New("url or language is empty")
+// phraseLanguage returns the language to save phrase with. With LanguageAuto
+// it's the detected language, and false if it can't be detected.
+func (r req) phraseLanguage(language, phrase string) (string, bool) {
+	if language != LanguageAuto {
+		return language, true
 	}
 
-	err = json.Unmarshal(r.Body, &dbPhrases)
-	if err != nil {
-		return []databasePhrase{}, err
+	result := langdetect.Detect(phrase)
+	if result.Language == "" {
+		r.logger.Info(fmt.Sprintf("Language not detected, skipping: phrase=\"%s\"", phrase))
+		return "", false
 	}
+	return result.Language, true
+}
 
-	// Here we jump the first line to not process the headers.
-	// Maybe a flag or describe in help message to warn the final user?
-	for _, item := range dbPhrases {
-		// Don't input in database if author or phrase is empty.
-		if item.Phrase == "" || item.Author == "" {
-			slog.Info(fmt.Sprintf("Author or phrase is empty: author=\"%s\" phrase=\"%s\"", item.Author, item.Phrase))
-			continue
-		}
+// checkBatchLanguage warns when most phrases don't look like language.
+func (c *Client) checkBatchLanguage(phrases []databasePhrase, language string) {
+	if language == LanguageAuto || len(phrases) == 0 {
+		return
+	}
 
-		phraseHash := generateHash(item.Phrase)
-		phrase := databasePhrase{
-			ContentHash: r.BodyHash,
-			Author:      item.Author,
-			Phrase:      item.Phrase,
-			PhraseHash:  phraseHash,
-			Language:    language,
+	detected := map[string]int{}
+	for _, p := range phrases {
+		result := langdetect.Detect(p.Phrase)
+		if result.Language != "" && result.Confidence >= minBatchConfidence {
+			detected[result.Language]++
 		}
-		dbPhrases = append(dbPhrases, phrase)
 	}
 
-	return dbPhrases, nil
+	for lang, n := range detected {
+		if lang != language && n*2 > len(phrases) {
+			c.logger.Warn(fmt.Sprintf("%d of %d phrases look like language %q, not %q. Use -language %s or -language %s.",
+				n, len(phrases), lang, language, lang, LanguageAuto))
+		}
+	}
 }
 
 func generateHash(data string) string {
@@ -0,0 +1,190 @@
+package motivar
+
+import (
+	"bufio"
+	"context"
+	"embed"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// The built-in lists of the content filter are in filters/<language>.txt.
+//
+//go:embed filters/*.txt
+var filterLists embed.FS
+
+// ContentFilter blocks phrases with profanity or offensive words, for
+// phrases shown on shared screens. The words of the built-in list of the
+// language of the phrase are blocked, besides Block and except Allow.
+//
+// Words ignore case and accents. A trailing * matches any word starting with
+// it, and words separated by spaces match a sequence of words, like
+// "son of a bitch".
+type ContentFilter struct {
+	// Block are words blocked in every language.
+	Block []string
+	// Allow are words never blocked, removed from the built-in lists. Words
+	// matched by a blocked prefix can be allowed too, like "cocktail" with
+	// "cock*".
+	Allow []string
+}
+
+// UnsafePhrase is a phrase blocked by a ContentFilter.
+type UnsafePhrase struct {
+	Phrase
+	// Words of the filter found in the phrase.
+	Words []string
+}
+
+// WithContentFilter sets the safe mode: phrases blocked by f are skipped
+// when importing and never returned by Random, RandomTranslated, Today,
+// Search and List.
+func WithContentFilter(f ContentFilter) Option {
+	return func(c *Client) {
+		c.filter = f.compile()
+	}
+}
+
+// blockedWord is a word, or sequence of words, of the filter.
+type blockedWord struct {
+	text  string
+	words []string
+	// prefix matches the last word as a prefix.
+	prefix bool
+}
+
+// contentFilter is a ContentFilter ready to match phrases.
+type contentFilter struct {
+	// blocked words of each language. "" has the words of every language.
+	blocked map[string][]blockedWord
+	allowed map[string]bool
+}
+
+func (f ContentFilter) compile() *contentFilter {
+	cf := &contentFilter{blocked: map[string][]blockedWord{}, allowed: map[string]bool{}}
+	for _, word := range f.Allow {
+		cf.allowed[normalizePhrase(word)] = true
+	}
+
+	files, _ := filterLists.ReadDir("filters")
+	for _, file := range files {
+		content, err := filterLists.ReadFile("filters/" + file.Name())
+		if err != nil {
+			continue
+		}
+		language := strings.TrimSuffix(file.Name(), ".txt")
+		scanner := bufio.NewScanner(strings.NewReader(string(content)))
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			cf.add(language, line)
+		}
+	}
+	for _, word := range f.Block {
+		cf.add("", word)
+	}
+	return cf
+}
+
+// add blocks word in language, unless it's allowed.
+func (f *contentFilter) add(language, word string) {
+	prefix := strings.HasSuffix(word, "*")
+	text := normalizePhrase(strings.TrimSuffix(word, "*"))
+	if text == "" || f.allowed[text] {
+		return
+	}
+	f.blocked[language] = append(f.blocked[language], blockedWord{text: word, words: strings.Fields(text), prefix: prefix})
+}
+
+// match returns the blocked words found in text, of language, or nil.
+func (f *contentFilter) match(language, text string) []string {
+	words := strings.Fields(normalizePhrase(text))
+
+	var found []string
+	for _, blocked := range [][]blockedWord{f.blocked[""], f.blocked[language]} {
+		for _, b := range blocked {
+			if !slices.Contains(found, b.text) && b.in(words, f.allowed) {
+				found = append(found, b.text)
+			}
+		}
+	}
+	return found
+}
+
+// in reports whether the sequence of words of b is in words.
+func (b blockedWord) in(words []string, allowed map[string]bool) bool {
+	last := len(b.words) - 1
+	for i := 0; i+last < len(words); i++ {
+		if allowed[words[i+last]] && len(b.words) == 1 {
+			continue
+		}
+		if !slices.Equal(words[i:i+last], b.words[:last]) {
+			continue
+		}
+		word := words[i+last]
+		if word == b.words[last] || (b.prefix && strings.HasPrefix(word, b.words[last])) {
+			return true
+		}
+	}
+	return false
+}
+
+// unsafe reports whether p is blocked by the content filter, if set.
+func (c *Client) unsafe(p Phrase) bool {
+	return c.filter != nil && c.filter.match(p.Language, p.Phrase) != nil
+}
+
+// safe removes the phrases blocked by the content filter, if set.
+func (c *Client) safe(phrases []Phrase) []Phrase {
+	if c.filter == nil {
+		return phrases
+	}
+	return slices.DeleteFunc(phrases, c.unsafe)
+}
+
+// safeToImport removes the phrases blocked by the content filter.
+func (c *Client) safeToImport(phrases []databasePhrase) []databasePhrase {
+	var blocked int
+	phrases = slices.DeleteFunc(phrases, func(p databasePhrase) bool {
+		words := c.filter.match(p.Language, p.Phrase)
+		if words != nil {
+			c.logger.Debug(fmt.Sprintf("Blocked by the content filter (%s): %s", strings.Join(words, ", "), p.Phrase))
+			blocked++
+		}
+		return words != nil
+	})
+	if blocked > 0 {
+		c.logger.Info(fmt.Sprintf("%d phrases blocked by the content filter.", blocked))
+	}
+	return phrases
+}
+
+// UnsafePhrases returns the database phrases of opts language blocked by f,
+// to review the phrases imported before the filter or its words changed.
+func (c *Client) UnsafePhrases(ctx context.Context, opts Options, f ContentFilter) ([]UnsafePhrase, error) {
+	language, err := c.languageOf(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := c.database(ctx)
+	if err != nil {
+		return nil, err
+	}
+	phrases, err := db.GetPhrases(ctx, language, opts.limits())
+	if err != nil {
+		return nil, err
+	}
+
+	filter := f.compile()
+	var unsafe []UnsafePhrase
+	for _, p := range phrases {
+		if words := filter.match(p.Language, p.Phrase); words != nil {
+			unsafe = append(unsafe, UnsafePhrase{Phrase: p, Words: words})
+		}
+	}
+	return unsafe, nil
+}
@@ -0,0 +1,92 @@
+package motivar
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestContentFilter(t *testing.T) {
+	filter := ContentFilter{
+		Block: []string{"Lorem*", "grão de areia", "cock*"},
+		Allow: []string{"cocktail", "bosta"},
+	}.compile()
+
+	tests := []struct {
+		language, text string
+		want           []string
+	}{
+		{"us", "Be yourself; everyone else is already taken.", nil},
+		{"us", "What the FUCKING hell.", []string{"fuck*"}},
+		{"us", "Don't be a son of a bitch, son.", []string{"bitch*", "son of a bitch"}},
+		{"us", "A class act, with a cocktail.", nil},
+		{"us", "Don't be a dick.", []string{"dick"}},
+		// Words of other languages aren't blocked.
+		{"us", "Que porra é essa", nil},
+		{"br", "Que PÔRRA é essa", []string{"porra*"}},
+		{"br", "Passei pelo viaduto", nil},
+		{"br", "Que bosta", nil},
+		{"br", "Um grão de areia, loremipsum", []string{"Lorem*", "grão de areia"}},
+		{"br", "Um grão de sal", nil},
+	}
+	for _, tt := range tests {
+		got := filter.match(tt.language, tt.text)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("match(%q, %q) = %q, want %q", tt.language, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSafeMode(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "quotes.csv")
+	content := "author,phrase\nOscar Wilde,Be yourself; everyone else is already taken.\nAnonymous,Shit happens.\nAnonymous,Lorem ipsum dolor sit amet.\n"
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := newTestClient(t, WithContentFilter(ContentFilter{}))
+	inserted, err := client.Import(ctx, Source{URL: file, Format: "csv", Language: "us"})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if inserted != 2 {
+		t.Errorf("Import: inserted %d phrases, want 2", inserted)
+	}
+
+	unsafe, err := client.UnsafePhrases(ctx, Options{Language: "us"}, ContentFilter{Block: []string{"lorem"}})
+	if err != nil {
+		t.Fatalf("UnsafePhrases: %v", err)
+	}
+	if len(unsafe) != 1 || unsafe[0].Phrase.Phrase != "Lorem ipsum dolor sit amet." || !slices.Equal(unsafe[0].Words, []string{"lorem"}) {
+		t.Errorf("UnsafePhrases: got %+v", unsafe)
+	}
+
+	// Phrases imported before the words were blocked aren't shown.
+	safe := newTestClient(t, WithDBPath(client.DBPath()), WithContentFilter(ContentFilter{Block: []string{"lorem"}}))
+	for range 50 {
+		p, err := safe.Random(ctx, Options{Language: "us"})
+		if err != nil {
+			t.Fatalf("Random: %v", err)
+		}
+		if p.Phrase == "Lorem ipsum dolor sit amet." {
+			t.Fatal("Random: returned a blocked phrase")
+		}
+	}
+	found, err := safe.Search(ctx, "ipsum", Options{Language: "us"})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(found) != 0 {
+		t.Errorf("Search: got %+v, want no phrases", found)
+	}
+	list, total, err := safe.List(ctx, ListOptions{Options: Options{Language: "us"}, Author: "Anonymous"})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != 0 {
+		t.Errorf("List: got %+v, want no phrases", list)
+	}
+}
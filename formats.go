@@ -0,0 +1,339 @@
+package motivar
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// FormatAuto as Source format detects the format from the URL extension or
+// from the content.
+const FormatAuto = "auto"
+
+// DefaultAuthorSeparators are tried, in order, to split the phrase from the
+// author in txt files when Source.AuthorSeparator is empty.
+var DefaultAuthorSeparators = []string{"—", "–", " -- ", " - "}
+
+// sourcePhrase is a phrase as written in the imported files.
+type sourcePhrase struct {
+	Author        string `json:"author" yaml:"author" toml:"author"`
+	Phrase        string `json:"phrase" yaml:"phrase" toml:"phrase"`
+	TranslationOf string `json:"translation_of" yaml:"translation_of" toml:"translation_of"`
+	Source        string `json:"source" yaml:"source" toml:"source"`
+	// ID of the item in feeds.
+	ID string `json:"-" yaml:"-" toml:"-"`
+}
+
+// parseOptions of the formats that need them.
+type parseOptions struct {
+	authorSeparator string
+	// feedPatterns extract the phrases of feed items. Nil uses
+	// DefaultFeedPatterns.
+	feedPatterns []*regexp.Regexp
+}
+
+// format of the imported or exported files.
+type format struct {
+	name string
+	// extensions of the files of this format, used by FormatAuto.
+	extensions []string
+	// parse returns the phrases of body.
+	parse func(body []byte, opts parseOptions) ([]sourcePhrase, error)
+	// sniff reports whether body looks like this format.
+	sniff func(body []byte) bool
+	// write the phrases in this format. Nil if it can't be exported.
+	write func(w io.Writer, phrases []Phrase) error
+	// feed formats are imported again and again, saving only the items newer
+	// than the ones of the last import.
+	feed bool
+}
+
+// formats registered, in the order tried by FormatAuto.
+var formats = []*format{
+	{name: "json", extensions: []string{".json"}, parse: parseJSON, sniff: sniffJSON, write: writeJSON},
+	{name: "jsonl", extensions: []string{".jsonl", ".ndjson"}, parse: parseJSONL, sniff: sniffJSONL, write: writeJSONL},
+	{name: "rss", extensions: []string{".rss", ".atom"}, parse: parseFeed, sniff: sniffFeed, feed: true},
+	{name: "toml", extensions: []string{".toml"}, parse: parseTOML, sniff: sniffTOML},
+	{name: "yaml", extensions: []string{".yaml", ".yml"}, parse: parseYAML, sniff: sniffYAML},
+	{name: "fortune", extensions: []string{".fortune"}, parse: parseFortune, sniff: sniffFortune, write: writeFortune},
+	{name: "csv", extensions: []string{".csv"}, parse: parseCSV, sniff: sniffCSV, write: writeCSV},
+	{name: "kindle", extensions: []string{".txt"}, parse: parseKindle, sniff: sniffKindle},
+	{name: "txt", extensions: []string{".txt"}, parse: parseTXT, sniff: func([]byte) bool { return true }},
+}
+
+// Formats returns the formats that can be imported.
+func Formats() []string {
+	names := make([]string, len(formats))
+	for i, f := range formats {
+		names[i] = f.name
+	}
+	return names
+}
+
+// ExportFormats returns the formats that can be exported.
+func ExportFormats() []string {
+	var names []string
+	for _, f := range formats {
+		if f.write != nil {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+func lookupFormat(name string) *format {
+	for _, f := range formats {
+		if f.name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// detectFormat returns the format of the file at url: by its extension, or
+// else the first one whose sniff accepts body.
+func detectFormat(url string, body []byte) *format {
+	if f := formatByExtension(url, body); f != nil {
+		return f
+	}
+
+	for _, f := range formats {
+		if f.sniff(body) {
+			return f
+		}
+	}
+	return nil
+}
+
+// formatByExtension returns the format of the file at url by its extension.
+// When formats share the extension, the first one whose sniff accepts body is
+// chosen.
+func formatByExtension(url string, body []byte) *format {
+	ext := strings.ToLower(path.Ext(strings.SplitN(url, "?", 2)[0]))
+	var byExt []*format
+	for _, f := range formats {
+		if slices.Contains(f.extensions, ext) {
+			byExt = append(byExt, f)
+		}
+	}
+	for _, f := range byExt {
+		if len(byExt) == 1 || f.sniff(body) {
+			return f
+		}
+	}
+	return nil
+}
+
+func parseJSON(body []byte, _ parseOptions) ([]sourcePhrase, error) {
+	var phrases []sourcePhrase
+	if err := json.Unmarshal(body, &phrases); err != nil {
+		return nil, fmt.Errorf("invalid JSON format: %w", err)
+	}
+	return phrases, nil
+}
+
+func sniffJSON(body []byte) bool {
+	body = bytes.TrimSpace(body)
+	return bytes.HasPrefix(body, []byte("[")) && json.Valid(body)
+}
+
+func writeJSON(w io.Writer, phrases []Phrase) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(phrases)
+}
+
+func parseJSONL(body []byte, _ parseOptions) ([]sourcePhrase, error) {
+	var phrases []sourcePhrase
+	err := eachLine(body, func(n int, line string) error {
+		if strings.TrimSpace(line) == "" {
+			return nil
+		}
+
+		var p sourcePhrase
+		if err := json.Unmarshal([]byte(line), &p); err != nil {
+			return fmt.Errorf("invalid JSON Lines format: line %d: %w", n, err)
+		}
+		phrases = append(phrases, p)
+		return nil
+	})
+	return phrases, err
+}
+
+func sniffJSONL(body []byte) bool {
+	lines := 0
+	err := eachLine(body, func(_ int, line string) error {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return nil
+		}
+		if !strings.HasPrefix(line, "{") || !json.Valid([]byte(line)) {
+			return errors.New("not JSON")
+		}
+		lines++
+		return nil
+	})
+	return err == nil && lines > 0
+}
+
+func writeJSONL(w io.Writer, phrases []Phrase) error {
+	encoder := json.NewEncoder(w)
+	for _, p := range phrases {
+		if err := encoder.Encode(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseTOML reads phrases as an array of tables:
+//
+//	[[phrases]]
+//	author = "Walt Disney"
+//	phrase = "It's kind of fun to do the impossible."
+func parseTOML(body []byte, _ parseOptions) ([]sourcePhrase, error) {
+	var doc struct {
+		Phrases []sourcePhrase `toml:"phrases"`
+	}
+	if _, err := toml.Decode(string(body), &doc); err != nil {
+		return nil, fmt.Errorf("invalid TOML format: %w", err)
+	}
+	return doc.Phrases, nil
+}
+
+func sniffTOML(body []byte) bool {
+	return bytes.Contains(body, []byte("[[phrases]]"))
+}
+
+// parseYAML reads a list of phrases, or a map with the list in "phrases".
+func parseYAML(body []byte, _ parseOptions) ([]sourcePhrase, error) {
+	var phrases []sourcePhrase
+	err := yaml.Unmarshal(body, &phrases)
+	if err == nil {
+		return phrases, nil
+	}
+
+	var doc struct {
+		Phrases []sourcePhrase `yaml:"phrases"`
+	}
+	if errDoc := yaml.Unmarshal(body, &doc); errDoc != nil {
+		return nil, fmt.Errorf("invalid YAML format: %w", err)
+	}
+	return doc.Phrases, nil
+}
+
+func sniffYAML(body []byte) bool {
+	body = bytes.TrimSpace(body)
+	if !bytes.HasPrefix(body, []byte("- ")) && !bytes.HasPrefix(body, []byte("---")) && !bytes.HasPrefix(body, []byte("phrases:")) {
+		return false
+	}
+	phrases, err := parseYAML(body, parseOptions{})
+	return err == nil && len(phrases) > 0
+}
+
+// parseCSV reads the columns author, phrase and, optionally, translation_of.
+// The first line is the header.
+func parseCSV(body []byte, _ parseOptions) ([]sourcePhrase, error) {
+	lines, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV format: %w", err)
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	var phrases []sourcePhrase
+	for _, line := range lines[1:] {
+		if len(line) != 2 && len(line) != 3 {
+			continue
+		}
+
+		p := sourcePhrase{Author: line[0], Phrase: line[1]}
+		if len(line) == 3 {
+			p.TranslationOf = line[2]
+		}
+		phrases = append(phrases, p)
+	}
+	return phrases, nil
+}
+
+func sniffCSV(body []byte) bool {
+	reader := csv.NewReader(bytes.NewReader(body))
+	lines, err := reader.ReadAll()
+	return err == nil && len(lines) > 1 && (len(lines[0]) == 2 || len(lines[0]) == 3)
+}
+
+func writeCSV(w io.Writer, phrases []Phrase) error {
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"author", "phrase"})
+	for _, p := range phrases {
+		_ = writer.Write([]string{p.Author, p.Phrase})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// parseTXT reads one phrase per line, like:
+//
+//	"Quote" — Author
+//
+// The author is after the last separator. Quotes around the phrase are
+// removed.
+func parseTXT(body []byte, opts parseOptions) ([]sourcePhrase, error) {
+	separators := DefaultAuthorSeparators
+	if opts.authorSeparator != "" {
+		separators = []string{opts.authorSeparator}
+	}
+
+	var phrases []sourcePhrase
+	err := eachLine(body, func(_ int, line string) error {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			return nil
+		}
+
+		p := sourcePhrase{Phrase: line}
+		for _, sep := range separators {
+			if i := strings.LastIndex(line, sep); i > 0 {
+				p = sourcePhrase{Phrase: line[:i], Author: line[i+len(sep):]}
+				break
+			}
+		}
+		p.Author = strings.TrimSpace(p.Author)
+		p.Phrase = trimQuotes(p.Phrase)
+		phrases = append(phrases, p)
+		return nil
+	})
+	return phrases, err
+}
+
+// trimQuotes removes spaces and quotes around s.
+func trimQuotes(s string) string {
+	return strings.TrimFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(`"“”‘’«»`, r)
+	})
+}
+
+// eachLine calls fn with each line of body and its number, from 1.
+func eachLine(body []byte, fn func(n int, line string) error) error {
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), BodyMaxLength)
+	for n := 1; scanner.Scan(); n++ {
+		if err := fn(n, scanner.Text()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
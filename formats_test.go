@@ -0,0 +1,121 @@
+package motivar
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"slices"
+	"testing"
+)
+
+var samplePhrases = []sourcePhrase{
+	{Author: "Walt Disney", Phrase: "The way to get started is to quit talking and begin doing."},
+	{Author: "Eleanor Roosevelt", Phrase: "The future belongs to those who believe in the beauty of their dreams."},
+	{Author: "Lao Tzu", Phrase: "The journey of a thousand miles begins with one step."},
+}
+
+func TestParseFormats(t *testing.T) {
+	for _, name := range []string{"jsonl", "yaml", "toml", "fortune", "txt"} {
+		t.Run(name, func(t *testing.T) {
+			body, err := os.ReadFile("samples/quotes-us." + name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			phrases, err := lookupFormat(name).parse(body, parseOptions{})
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if !slices.Equal(phrases, samplePhrases) {
+				t.Errorf("parse:\ngot  %q\nwant %q", phrases, samplePhrases)
+			}
+
+			if f := detectFormat("http://example.com/quotes", body); f == nil || f.name != name {
+				t.Errorf("detectFormat: got %v, want %s", f, name)
+			}
+		})
+	}
+}
+
+func TestParseTXTSeparator(t *testing.T) {
+	body := []byte("Be yourself; everyone else is already taken. | Oscar Wilde\n\nNo author here\n")
+	phrases, err := parseTXT(body, parseOptions{authorSeparator: " | "})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []sourcePhrase{
+		{Author: "Oscar Wilde", Phrase: "Be yourself; everyone else is already taken."},
+		{Phrase: "No author here"},
+	}
+	if !slices.Equal(phrases, want) {
+		t.Errorf("got %q, want %q", phrases, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"json":  `[{"author": "A", "phrase": }]`,
+		"jsonl": "{\"author\": \"A\", \"phrase\": \"B\"}\n{oops}\n",
+		"yaml":  "- author: [A\n",
+		"toml":  "[[phrases]]\nauthor = \n",
+		"csv":   "author,phrase\n\"A,B\n",
+	}
+	for name, body := range tests {
+		if _, err := lookupFormat(name).parse([]byte(body), parseOptions{}); err == nil {
+			t.Errorf("%s: want error", name)
+		}
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		url, body, want string
+	}{
+		{"http://x/quotes.yml?raw=1", "", "yaml"},
+		{"http://x/quotes.NDJSON", "", "jsonl"},
+		{"http://x/raw", `[{"author": "A", "phrase": "B"}]`, "json"},
+		{"http://x/raw", "author,phrase\nA,B\n", "csv"},
+		{"http://x/raw", "B\n\t-- A\n%\nD\n", "fortune"},
+		{"http://x/raw", "B — A\n", "txt"},
+	}
+	for _, tt := range tests {
+		if f := detectFormat(tt.url, []byte(tt.body)); f == nil || f.name != tt.want {
+			t.Errorf("detectFormat(%q, %q): got %v, want %s", tt.url, tt.body, f, tt.want)
+		}
+	}
+}
+
+func TestImportFormatAuto(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("samples")))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := newTestClient(t, WithHTTPClient(server.Client()))
+
+	inserted, err := client.Import(ctx, Source{URL: server.URL + "/quotes-us.toml", Format: FormatAuto, Language: "us"})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if inserted != len(samplePhrases) {
+		t.Errorf("Import: got %d phrases, want %d", inserted, len(samplePhrases))
+	}
+
+	var buf bytes.Buffer
+	if err := client.Export(ctx, &buf, "jsonl", Options{Language: "us"}); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	phrases, err := parseJSONL(buf.Bytes(), parseOptions{})
+	if err != nil {
+		t.Fatalf("exported JSON Lines: %v", err)
+	}
+	if len(phrases) != len(embeddedPhrases("us"))+len(samplePhrases) {
+		t.Errorf("Export: got %d phrases", len(phrases))
+	}
+
+	if err := client.Export(ctx, &buf, "yaml", Options{}); err == nil {
+		t.Error("Export yaml: want error")
+	}
+}
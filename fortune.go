@@ -0,0 +1,126 @@
+package motivar
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// fortune(6) files have texts separated by lines with only "%". The author
+// is in the last line of the text, like "	-- Author".
+
+const (
+	fortuneDelimiter = '%'
+	// strfileVersion of the .dat header written by WriteStrfile.
+	strfileVersion = 2
+)
+
+var fortuneAttribution = regexp.MustCompile(`^\s*(?:--|―|—)\s*(.+?)\s*$`)
+
+// parseFortune reads a fortune file. Lines of a text are joined with spaces.
+func parseFortune(body []byte, _ parseOptions) ([]sourcePhrase, error) {
+	var (
+		phrases []sourcePhrase
+		lines   []string
+	)
+
+	flush := func() {
+		author := ""
+		if n := len(lines); n > 1 {
+			if m := fortuneAttribution.FindStringSubmatch(lines[n-1]); m != nil {
+				author = m[1]
+				lines = lines[:n-1]
+			}
+		}
+
+		phrase := strings.Join(strings.Fields(strings.Join(lines, " ")), " ")
+		if phrase != "" || author != "" {
+			phrases = append(phrases, sourcePhrase{Author: author, Phrase: phrase})
+		}
+		lines = lines[:0]
+	}
+
+	err := eachLine(body, func(_ int, line string) error {
+		if line == string(fortuneDelimiter) {
+			flush()
+			return nil
+		}
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	flush()
+	return phrases, nil
+}
+
+func sniffFortune(body []byte) bool {
+	return bytes.Contains(body, []byte("\n%\n"))
+}
+
+func writeFortune(w io.Writer, phrases []Phrase) error {
+	bw := bufio.NewWriter(w)
+	for _, p := range phrases {
+		fmt.Fprintf(bw, "%s\n\t\t-- %s\n%c\n", p.Phrase, p.Author, fortuneDelimiter)
+	}
+	return bw.Flush()
+}
+
+// WriteStrfile writes the .dat index of the fortune file, as written by
+// strfile(1), so fortune(6) can read it.
+func WriteStrfile(w io.Writer, fortune io.Reader) error {
+	var (
+		offsets  = []uint32{0}
+		longest  uint32
+		shortest uint32 = ^uint32(0)
+		pos      uint32
+		last     uint32
+	)
+
+	// Like strfile, empty texts are skipped and the last offset is the end of
+	// the file.
+	reader := bufio.NewReader(fortune)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		pos += uint32(len(line))
+
+		eof := err == io.EOF
+		if eof || line == string(fortuneDelimiter)+"\n" {
+			length := pos - last
+			if !eof {
+				length -= uint32(len(line))
+			}
+			last = pos
+
+			if length > 0 {
+				offsets = append(offsets, pos)
+				longest = max(longest, length)
+				shortest = min(shortest, length)
+			}
+		}
+		if eof {
+			break
+		}
+	}
+
+	if len(offsets) == 1 {
+		shortest = 0
+	}
+
+	header := []uint32{strfileVersion, uint32(len(offsets) - 1), longest, shortest, 0}
+	if err := binary.Write(w, binary.BigEndian, header); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte{fortuneDelimiter, 0, 0, 0}); err != nil {
+		return err
+	}
+	return binary.Write(w, binary.BigEndian, offsets)
+}
@@ -0,0 +1,75 @@
+package motivar
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseFortune(t *testing.T) {
+	body := []byte("%\nA fortune without author.\n%\n\nTwo\n  lines.\n\t\t― Someone, \"A Book\"\n%\n%\n")
+	phrases, err := parseFortune(body, parseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []sourcePhrase{
+		{Phrase: "A fortune without author."},
+		{Author: `Someone, "A Book"`, Phrase: "Two lines."},
+	}
+	if !slices.Equal(phrases, want) {
+		t.Errorf("got %q, want %q", phrases, want)
+	}
+}
+
+func TestWriteStrfile(t *testing.T) {
+	phrases := []Phrase{
+		{Author: "Walt Disney", Phrase: "The way to get started is to quit talking and begin doing."},
+		{Author: "Lao Tzu", Phrase: "The journey of a thousand miles begins with one step."},
+	}
+
+	var fortune, dat bytes.Buffer
+	if err := writeFortune(&fortune, phrases); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteStrfile(&dat, bytes.NewReader(fortune.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each text ends at its "%" line, and the next one starts after it.
+	texts := strings.SplitAfter(fortune.String(), "%\n")
+	first := uint32(len(texts[0]) - len("%\n"))
+	second := uint32(len(texts[1]) - len("%\n"))
+
+	var got struct {
+		Version, NumStr, LongLen, ShortLen, Flags uint32
+		Delim                                     [4]byte
+		Offsets                                   [3]uint32
+	}
+	if err := binary.Read(&dat, binary.BigEndian, &got); err != nil {
+		t.Fatal(err)
+	}
+	if dat.Len() != 0 {
+		t.Errorf("%d bytes after the offsets", dat.Len())
+	}
+
+	if got.Version != strfileVersion || got.NumStr != 2 || got.Flags != 0 || got.Delim != [4]byte{'%'} {
+		t.Errorf("header: got %+v", got)
+	}
+	if got.LongLen != max(first, second) || got.ShortLen != min(first, second) {
+		t.Errorf("lengths: got %d and %d, want %d and %d", got.LongLen, got.ShortLen, max(first, second), min(first, second))
+	}
+	if want := [3]uint32{0, uint32(len(texts[0])), uint32(fortune.Len())}; got.Offsets != want {
+		t.Errorf("offsets: got %v, want %v", got.Offsets, want)
+	}
+
+	parsed, err := parseFortune(fortune.Bytes(), parseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed) != 2 || parsed[1].Author != "Lao Tzu" || parsed[1].Phrase != phrases[1].Phrase {
+		t.Errorf("parse of the written file: got %q", parsed)
+	}
+}
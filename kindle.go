@@ -0,0 +1,248 @@
+package motivar
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Kindle saves highlights, notes and bookmarks in "My Clippings.txt", in the
+// language of the device:
+//
+//	Book Title (Last, First)
+//	- Your Highlight on page 12 | Location 170-172 | Added on Sunday, March 5, 2017 10:33:47 AM
+//
+//	Text of the highlight
+//	==========
+
+const kindleSeparator = "=========="
+
+// kindleHighlightWords name a highlight in the metadata line. Notes and
+// bookmarks don't have them.
+var kindleHighlightWords = []string{"highlight", "destaque", "subrayado", "markierung", "surlignement", "evidenziazione"}
+
+var (
+	kindleHeader   = regexp.MustCompile(`^(.*?)\s*\(([^()]*)\)$`)
+	kindleLocation = regexp.MustCompile(`(?i)(?:location|loc\.|posição|posición|position|emplacement|posizione)\s*(\d+)(?:-(\d+))?`)
+	kindleTime     = regexp.MustCompile(`(?i)(\d{1,2}):(\d{2})(?::(\d{2}))?\s*([ap])?\.?m?\.?`)
+	kindleYear     = regexp.MustCompile(`\b\d{4}\b`)
+)
+
+// kindleMonths by name, in the languages of Kindle.
+var kindleMonths = map[string]time.Month{}
+
+func init() {
+	for _, names := range [][]string{
+		{"january", "february", "march", "april", "may", "june", "july", "august", "september", "october", "november", "december"},
+		{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		{"januar", "februar", "märz", "april", "mai", "juni", "juli", "august", "september", "oktober", "november", "dezember"},
+		{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+	} {
+		for i, name := range names {
+			kindleMonths[name] = time.Month(i + 1)
+		}
+	}
+}
+
+// kindleClipping is a highlight of My Clippings.txt.
+type kindleClipping struct {
+	title  string
+	author string
+	// start and end of the location, 0 if the book has only pages.
+	start, end int
+	added      time.Time
+	text       string
+}
+
+// overlaps reports whether c and o are the same passage highlighted again,
+// with more or fewer words.
+func (c kindleClipping) overlaps(o kindleClipping) bool {
+	if c.title != o.title {
+		return false
+	}
+	// Different passages may share the last location of the other.
+	if c.start > 0 && o.start > 0 && (c.start == o.start || c.start < o.end && o.start < c.end) {
+		return true
+	}
+	a, b := strings.ToLower(c.text), strings.ToLower(o.text)
+	return strings.Contains(a, b) || strings.Contains(b, a)
+}
+
+// parseKindle reads the highlights of a My Clippings.txt file. The book
+// title is the source of the phrases. When a passage was highlighted again,
+// only the newest highlight is kept.
+func parseKindle(body []byte, _ parseOptions) ([]sourcePhrase, error) {
+	var (
+		clippings []kindleClipping
+		lines     []string
+	)
+
+	flush := func() {
+		c, ok := parseKindleClipping(lines)
+		lines = lines[:0]
+		if !ok {
+			return
+		}
+
+		for i, kept := range clippings {
+			if kept.overlaps(c) {
+				if c.added.IsZero() || kept.added.IsZero() || !c.added.Before(kept.added) {
+					clippings[i] = c
+				}
+				return
+			}
+		}
+		clippings = append(clippings, c)
+	}
+
+	err := eachLine(body, func(_ int, line string) error {
+		if strings.TrimSpace(line) == kindleSeparator {
+			flush()
+			return nil
+		}
+		lines = append(lines, line)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	flush()
+
+	phrases := make([]sourcePhrase, len(clippings))
+	for i, c := range clippings {
+		phrases[i] = sourcePhrase{Author: c.author, Phrase: c.text, Source: c.title}
+	}
+	return phrases, nil
+}
+
+// parseKindleClipping parses the lines of an entry, returning false if it
+// isn't a highlight.
+func parseKindleClipping(lines []string) (kindleClipping, bool) {
+	for len(lines) > 0 && strings.TrimSpace(strings.TrimPrefix(lines[0], "\ufeff")) == "" {
+		lines = lines[1:]
+	}
+	if len(lines) < 3 {
+		return kindleClipping{}, false
+	}
+
+	header := strings.TrimSpace(strings.TrimPrefix(lines[0], "\ufeff"))
+	meta := strings.TrimSpace(lines[1])
+	lower := strings.ToLower(meta)
+	if !strings.HasPrefix(meta, "-") || !containsAny(lower, kindleHighlightWords) {
+		return kindleClipping{}, false
+	}
+
+	c := kindleClipping{
+		title: header,
+		text:  strings.Join(strings.Fields(strings.Join(lines[2:], " ")), " "),
+	}
+	if c.text == "" {
+		return kindleClipping{}, false
+	}
+	if m := kindleHeader.FindStringSubmatch(header); m != nil {
+		c.title, c.author = m[1], kindleAuthor(m[2])
+	}
+	// Personal documents have no author, and phrases without one are skipped.
+	if c.author == "" {
+		c.author = c.title
+	}
+
+	if m := kindleLocation.FindStringSubmatch(meta); m != nil {
+		c.start, _ = strconv.Atoi(m[1])
+		c.end = c.start
+		if m[2] != "" {
+			// Old Kindles abbreviate the end, like 1520-25.
+			end := m[1][:max(len(m[1])-len(m[2]), 0)] + m[2]
+			c.end, _ = strconv.Atoi(end)
+		}
+	}
+
+	parts := strings.Split(meta, "|")
+	c.added, _ = parseKindleDate(parts[len(parts)-1])
+	return c, true
+}
+
+// kindleAuthor turns "Last, First" into "First Last". Many authors are
+// separated by ";".
+func kindleAuthor(s string) string {
+	var authors []string
+	for _, author := range strings.Split(s, ";") {
+		author = strings.TrimSpace(author)
+		if last, first, ok := strings.Cut(author, ","); ok && !strings.Contains(first, ",") {
+			author = strings.TrimSpace(first) + " " + strings.TrimSpace(last)
+		}
+		if author != "" {
+			authors = append(authors, author)
+		}
+	}
+	return strings.Join(authors, " & ")
+}
+
+// parseKindleDate parses the date a clipping was added, in the formats of
+// the languages of Kindle, like:
+//
+//	Added on Sunday, March 5, 2017 10:33:47 PM
+//	Adicionado: domingo, 5 de março de 2017 22:33:47
+//	Hinzugefügt am Sonntag, 5. März 2017 22:33:47
+func parseKindleDate(s string) (time.Time, bool) {
+	hour, minute, sec := 0, 0, 0
+	if m := kindleTime.FindStringSubmatch(s); m != nil {
+		hour, _ = strconv.Atoi(m[1])
+		minute, _ = strconv.Atoi(m[2])
+		sec, _ = strconv.Atoi(m[3])
+		switch strings.ToLower(m[4]) {
+		case "p":
+			if hour < 12 {
+				hour += 12
+			}
+		case "a":
+			if hour == 12 {
+				hour = 0
+			}
+		}
+		s = strings.Replace(s, m[0], " ", 1)
+	}
+
+	yearText := kindleYear.FindString(s)
+	if yearText == "" {
+		return time.Time{}, false
+	}
+	year, _ := strconv.Atoi(yearText)
+	s = strings.Replace(s, yearText, " ", 1)
+
+	var (
+		month time.Month
+		day   int
+	)
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if m, ok := kindleMonths[word]; ok && month == 0 {
+			month = m
+		} else if n, err := strconv.Atoi(word); err == nil && day == 0 && n >= 1 && n <= 31 {
+			day = n
+		}
+	}
+	if month == 0 || day == 0 {
+		return time.Time{}, false
+	}
+	return time.Date(year, month, day, hour, minute, sec, 0, time.Local), true
+}
+
+func sniffKindle(body []byte) bool {
+	return strings.Contains(string(body), "\n"+kindleSeparator)
+}
+
+func containsAny(s string, words []string) bool {
+	for _, w := range words {
+		if strings.Contains(s, w) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package motivar
+
+import (
+	"context"
+	"os"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseKindle(t *testing.T) {
+	body, err := os.ReadFile("samples/clippings-kindle.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	phrases, err := parseKindle(body, parseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []sourcePhrase{
+		{Author: "Stephen R. Covey", Phrase: "Begin with the end in mind. Start with a clear destination.", Source: "The 7 Habits of Highly Effective People"},
+		{Author: "Charles Duhigg", Phrase: "A mudança pode não ser rápida e nem sempre é fácil.", Source: "O Poder do Hábito"},
+		{Author: "Rolf Dobelli", Phrase: "Wer nur einen Hammer hat, sieht überall Nägel.", Source: "Die Kunst des klaren Denkens"},
+	}
+	if !slices.Equal(phrases, want) {
+		t.Errorf("got  %q\nwant %q", phrases, want)
+	}
+
+	if f := detectFormat("/media/Kindle/documents/My Clippings.txt", body); f == nil || f.name != "kindle" {
+		t.Errorf("detectFormat: got %v, want kindle", f)
+	}
+}
+
+func TestParseKindleRehighlight(t *testing.T) {
+	// The older highlight comes after in the file, as when synced from
+	// another device.
+	body := []byte(`Book (Author)
+- Your Highlight at location 10-12 | Added on Monday, March 6, 2017 9:00:00 AM
+
+Longer passage of the book.
+==========
+Book (Author)
+- Your Highlight at location 10-11 | Added on Sunday, March 5, 2017 9:00:00 AM
+
+Longer passage
+==========
+Book (Author)
+- Your Highlight at location 12-14 | Added on Sunday, March 5, 2017 9:00:00 AM
+
+Another passage.
+==========
+Notes of the meeting
+- Your Highlight at location 3-4 | Added on Sunday, March 5, 2017 9:00:00 AM
+
+A document without author.
+==========
+`)
+	phrases, err := parseKindle(body, parseOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []sourcePhrase{
+		{Author: "Author", Phrase: "Longer passage of the book.", Source: "Book"},
+		{Author: "Author", Phrase: "Another passage.", Source: "Book"},
+		{Author: "Notes of the meeting", Phrase: "A document without author.", Source: "Notes of the meeting"},
+	}
+	if !slices.Equal(phrases, want) {
+		t.Errorf("got  %q\nwant %q", phrases, want)
+	}
+}
+
+func TestParseKindleDate(t *testing.T) {
+	tests := map[string]time.Time{
+		" Added on Sunday, March 5, 2017 10:33:47 PM":       time.Date(2017, 3, 5, 22, 33, 47, 0, time.Local),
+		" Added on Sunday, 5 March 2017 00:33:47":           time.Date(2017, 3, 5, 0, 33, 47, 0, time.Local),
+		" Added on Sunday, March 5, 2017 12:10:00 AM":       time.Date(2017, 3, 5, 0, 10, 0, 0, time.Local),
+		" Adicionado: domingo, 5 de março de 2017 21:15:02": time.Date(2017, 3, 5, 21, 15, 2, 0, time.Local),
+		" Añadido el lunes, 6 de marzo de 2017 10:33:47":    time.Date(2017, 3, 6, 10, 33, 47, 0, time.Local),
+		" Hinzugefügt am Sonntag, 5. März 2017 22:33:47":    time.Date(2017, 3, 5, 22, 33, 47, 0, time.Local),
+		" Ajouté le dimanche 5 mars 2017 10:33:47":          time.Date(2017, 3, 5, 10, 33, 47, 0, time.Local),
+		" Aggiunto il domenica 5 marzo 2017 10:33:47":       time.Date(2017, 3, 5, 10, 33, 47, 0, time.Local),
+	}
+	for s, want := range tests {
+		if got, ok := parseKindleDate(s); !ok || !got.Equal(want) {
+			t.Errorf("parseKindleDate(%q) = %v, %v; want %v", s, got, ok, want)
+		}
+	}
+
+	if _, ok := parseKindleDate("Location 170"); ok {
+		t.Error("parseKindleDate without date: want false")
+	}
+}
+
+func TestImportKindle(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	inserted, err := client.Import(ctx, Source{URL: "samples/clippings-kindle.txt", Format: "kindle", Language: "br"})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if inserted != 3 {
+		t.Errorf("Import: got %d phrases, want 3", inserted)
+	}
+
+	found, err := client.Search(ctx, "Duhigg", Options{Language: "br"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].Source != "O Poder do Hábito" {
+		t.Errorf("Search: got %+v", found)
+	}
+}
@@ -0,0 +1,197 @@
+// Package langdetect detects the language of short texts like phrases. It
+// works offline: each supported language has a list of stop words and a
+// profile of character trigrams built from the embedded phrases.
+package langdetect
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/wvoliveira/motivar/data"
+)
+
+// profileSize is how many of the most frequent trigrams a profile keeps.
+const profileSize = 600
+
+// minScore is the score below which a text is considered of unknown language.
+const minScore = 0.08
+
+// Result of a detection
+type Result struct {
+	// Language detected, or "" when unknown.
+	Language string
+	// Confidence from 0 to 1: how much the best language scored above the
+	// second one.
+	Confidence float64
+}
+
+type model struct {
+	stopWords map[string]bool
+	trigrams  map[string]float64
+	norm      float64
+}
+
+// Detector detects languages with a set of models
+type Detector struct {
+	models map[string]model
+}
+
+var stopWords = map[string][]string{
+	"br": {
+		"a", "o", "as", "os", "de", "da", "do", "das", "dos", "e", "é", "em", "no", "na",
+		"nos", "nas", "um", "uma", "que", "para", "por", "com", "não", "se", "mais",
+		"mas", "como", "ao", "à", "seu", "sua", "ele", "ela", "você", "quem", "isso",
+		"ser", "são", "está", "tudo", "nunca", "sempre", "muito", "pelo", "pela",
+		"nada", "também", "quando", "onde", "porque", "sem", "eu", "nós", "já", "só",
+		"foi", "há", "seja", "sobre", "até", "lhe", "seus", "suas", "aquilo", "isto",
+	},
+	"us": {
+		"the", "a", "an", "of", "to", "and", "is", "in", "it", "you", "that", "he",
+		"she", "was", "for", "on", "are", "with", "as", "i", "his", "her", "be", "at",
+		"by", "not", "this", "but", "from", "or", "have", "what", "your", "all", "can",
+		"do", "if", "will", "my", "we", "they", "who", "when", "more", "one", "there",
+		"never", "only", "always", "no", "don't", "than", "them", "their", "our",
+		"would", "has", "been", "into", "about", "yourself", "things", "way",
+	},
+}
+
+// defaultDetector is built on the first Detect, not on init, as most runs of
+// motivar never detect a language.
+var defaultDetector = sync.OnceValue(func() *Detector { return New(data.Languages) })
+
+// New returns a Detector with a model for each language of corpus. Languages
+// without stop words are detected only by their trigrams.
+func New(corpus map[string][]data.Phrase) *Detector {
+	d := &Detector{models: map[string]model{}}
+
+	for language, phrases := range corpus {
+		m := model{stopWords: map[string]bool{}}
+		for _, word := range stopWords[language] {
+			m.stopWords[word] = true
+		}
+
+		counts := map[string]float64{}
+		for _, p := range phrases {
+			for trigram, n := range trigrams(words(p.Phrase)) {
+				counts[trigram] += n
+			}
+		}
+		m.trigrams = top(counts, profileSize)
+		m.norm = norm(m.trigrams)
+
+		d.models[language] = m
+	}
+	return d
+}
+
+// Detect detects the language of text with the built-in models.
+func Detect(text string) Result {
+	return defaultDetector().Detect(text)
+}
+
+// Detect detects the language of text.
+func (d *Detector) Detect(text string) Result {
+	ws := words(text)
+	if len(ws) == 0 {
+		return Result{}
+	}
+	counts := trigrams(ws)
+	textNorm := norm(counts)
+
+	type score struct {
+		language string
+		value    float64
+	}
+	var scores []score
+
+	for language, m := range d.models {
+		var hits float64
+		for _, w := range ws {
+			if m.stopWords[w] {
+				hits++
+			}
+		}
+
+		var dot float64
+		for trigram, n := range counts {
+			dot += n * m.trigrams[trigram]
+		}
+		var similarity float64
+		if textNorm > 0 && m.norm > 0 {
+			similarity = dot / (textNorm * m.norm)
+		}
+
+		value := similarity
+		if len(m.stopWords) > 0 {
+			value = (hits/float64(len(ws)) + similarity) / 2
+		}
+		scores = append(scores, score{language, value})
+	}
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].value != scores[j].value {
+			return scores[i].value > scores[j].value
+		}
+		return scores[i].language < scores[j].language
+	})
+
+	if len(scores) == 0 || scores[0].value < minScore {
+		return Result{}
+	}
+
+	result := Result{Language: scores[0].language, Confidence: 1}
+	if len(scores) > 1 {
+		result.Confidence = (scores[0].value - scores[1].value) / scores[0].value
+	}
+	return result
+}
+
+// words returns the lower case words of text.
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+}
+
+// trigrams counts the character trigrams of words, padded with spaces.
+func trigrams(words []string) map[string]float64 {
+	counts := map[string]float64{}
+	for _, w := range words {
+		runes := []rune(" " + w + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			counts[string(runes[i:i+3])]++
+		}
+	}
+	return counts
+}
+
+// top keeps the n most frequent trigrams.
+func top(counts map[string]float64, n int) map[string]float64 {
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+
+	result := map[string]float64{}
+	for _, k := range keys[:min(n, len(keys))] {
+		result[k] = counts[k]
+	}
+	return result
+}
+
+func norm(v map[string]float64) float64 {
+	var sum float64
+	for _, n := range v {
+		sum += n * n
+	}
+	return math.Sqrt(sum)
+}
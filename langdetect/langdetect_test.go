@@ -0,0 +1,27 @@
+package langdetect
+
+import (
+	"testing"
+)
+
+func TestDetect(t *testing.T) {
+	texts := []struct {
+		text string
+		lang string
+	}{
+		{"O sucesso é a soma de pequenos esforços repetidos dia após dia.", "br"},
+		{"Não espere por oportunidades, crie-as.", "br"},
+		{"A vida é uma aventura ousada ou não é nada.", "br"},
+		{"Success is the sum of small efforts repeated day in and day out.", "us"},
+		{"Don't wait for opportunity. Create it.", "us"},
+		{"The only way to do great work is to love what you do.", "us"},
+		{"", ""},
+		{"12345 !!!", ""},
+	}
+
+	for _, tt := range texts {
+		if got := Detect(tt.text); got.Language != tt.lang {
+			t.Errorf("Text %q: got %q (confidence %.2f), want %q.", tt.text, got.Language, got.Confidence, tt.lang)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package motivar
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Units of Options.LengthUnit.
+const (
+	LengthCharacters = "chars"
+	LengthWords      = "words"
+)
+
+// ellipsis ends the phrases shortened by Options.Truncate.
+const ellipsis = "…"
+
+// lengthLimits are the limits of Options to select phrases. Zero is no limit.
+type lengthLimits struct {
+	min, max int
+	words    bool
+}
+
+// checkLength returns an error if the length options are invalid.
+func (o Options) checkLength() error {
+	if o.MinLength < 0 || o.MaxLength < 0 {
+		return errors.New("min and max length must not be negative")
+	}
+	if o.MaxLength > 0 && o.MinLength > o.MaxLength {
+		return fmt.Errorf("min length %d is greater than max length %d", o.MinLength, o.MaxLength)
+	}
+	if o.LengthUnit != "" && o.LengthUnit != LengthCharacters && o.LengthUnit != LengthWords {
+		return fmt.Errorf("length unit %q not supported. Use %s or %s", o.LengthUnit, LengthCharacters, LengthWords)
+	}
+	return nil
+}
+
+// limits returns the limits to select phrases. Phrases longer than
+// MaxLength are selected to be truncated with Truncate.
+func (o Options) limits() lengthLimits {
+	l := lengthLimits{min: o.MinLength, max: o.MaxLength, words: o.LengthUnit == LengthWords}
+	if o.Truncate {
+		l.max = 0
+	}
+	return l
+}
+
+// length returns the length of text in characters or words.
+func (l lengthLimits) length(text string) int {
+	if l.words {
+		return len(strings.Fields(text))
+	}
+	return utf8.RuneCountInString(text)
+}
+
+// fits reports whether p is within the limits.
+func (l lengthLimits) fits(p Phrase) bool {
+	n := l.length(p.Phrase)
+	return n >= l.min && (l.max == 0 || n <= l.max)
+}
+
+// filter removes the phrases out of the limits.
+func (l lengthLimits) filter(phrases []Phrase) []Phrase {
+	if l.min == 0 && l.max == 0 {
+		return phrases
+	}
+	var within []Phrase
+	for _, p := range phrases {
+		if l.fits(p) {
+			within = append(within, p)
+		}
+	}
+	return within
+}
+
+// sql returns the conditions of the limits for the phrases table, to append
+// to a WHERE clause, and their args. Words are counted by filter and fits
+// after the query, as SQLite can't split the phrase on every kind of space
+// like strings.Fields.
+func (l lengthLimits) sql() (string, []any) {
+	if l.words {
+		return "", nil
+	}
+	const length = "LENGTH(phrase)"
+
+	var (
+		conditions string
+		args       []any
+	)
+	if l.min > 0 {
+		conditions += " AND " + length + " >= ?"
+		args = append(args, l.min)
+	}
+	if l.max > 0 {
+		conditions += " AND " + length + " <= ?"
+		args = append(args, l.max)
+	}
+	return conditions, args
+}
+
+// truncate shortens p to MaxLength with Truncate.
+func (o Options) truncate(p Phrase) Phrase {
+	if !o.Truncate || o.MaxLength == 0 {
+		return p
+	}
+	p.Phrase = Truncate(p.Phrase, o.MaxLength, o.LengthUnit)
+	return p
+}
+
+// truncateAll shortens the phrases to MaxLength with Truncate.
+func (o Options) truncateAll(phrases []Phrase) []Phrase {
+	for i, p := range phrases {
+		phrases[i] = o.truncate(p)
+	}
+	return phrases
+}
+
+// Truncate shortens text to max characters, or words with LengthWords as
+// unit, on a word boundary and ending with an ellipsis. The ellipsis counts
+// as a character. A first word longer than max is cut.
+func Truncate(text string, max int, unit string) string {
+	if unit == LengthWords {
+		words := strings.Fields(text)
+		if len(words) <= max {
+			return text
+		}
+		return trimEnd(strings.Join(words[:max], " ")) + ellipsis
+	}
+
+	runes := []rune(text)
+	if len(runes) <= max {
+		return text
+	}
+	if max <= 1 {
+		return ellipsis
+	}
+	cut := string(runes[:max-1])
+	// Keep the whole word when the cut is right before a space.
+	if !unicode.IsSpace(runes[max-1]) {
+		if i := strings.LastIndexFunc(cut, unicode.IsSpace); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	return trimEnd(cut) + ellipsis
+}
+
+// trimEnd removes the spaces and punctuation from the end of s, to end it
+// with an ellipsis.
+func trimEnd(s string) string {
+	return strings.TrimRightFunc(s, func(r rune) bool {
+		return unicode.IsSpace(r) || strings.ContainsRune(",;:.!?-–—", r)
+	})
+}
@@ -0,0 +1,111 @@
+package motivar
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncate(t *testing.T) {
+	const text = "Be yourself; everyone else is already taken."
+	tests := []struct {
+		max  int
+		unit string
+		want string
+	}{
+		{100, LengthCharacters, text},
+		{len(text), LengthCharacters, text},
+		{20, LengthCharacters, "Be yourself…"},
+		{25, LengthCharacters, "Be yourself; everyone…"},
+		{23, LengthCharacters, "Be yourself; everyone…"},
+		{5, LengthCharacters, "Be…"},
+		{3, "", "Be…"},
+		{2, "", "B…"},
+		{1, "", "…"},
+		{2, LengthWords, "Be yourself…"},
+		{4, LengthWords, "Be yourself; everyone else…"},
+		{7, LengthWords, text},
+	}
+	for _, tt := range tests {
+		got := Truncate(text, tt.max, tt.unit)
+		if got != tt.want {
+			t.Errorf("Truncate(%d, %q) = %q, want %q", tt.max, tt.unit, got, tt.want)
+		}
+		if tt.unit != LengthWords && utf8.RuneCountInString(got) > tt.max {
+			t.Errorf("Truncate(%d, %q) = %q, longer than max", tt.max, tt.unit, got)
+		}
+	}
+
+	if got := Truncate("Supercalifragilistic", 10, LengthCharacters); got != "Supercali…" {
+		t.Errorf("long word: got %q", got)
+	}
+}
+
+func TestLengthLimits(t *testing.T) {
+	ctx := context.Background()
+	file := filepath.Join(t.TempDir(), "quotes.csv")
+	content := "author,phrase\nA,Short one.\nB,A phrase with exactly six words.\nC,Ação é tudo.\n" +
+		"D,\"This phrase is a lot longer than the others and goes on and on, saying nothing at all for a status bar.\"\n" +
+		"E,Two  spaces.\nF,\"Tab\tseparated words.\"\n"
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client := newTestClient(t)
+	if _, err := client.Import(ctx, Source{URL: file, Format: "csv", Language: "us"}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	db, err := client.database(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]Options{
+		"max chars":      {MaxLength: 12},
+		"min chars":      {MinLength: 40},
+		"range of words": {MinLength: 3, MaxLength: 6, LengthUnit: LengthWords},
+		"spaced words":   {MinLength: 2, MaxLength: 2, LengthUnit: LengthWords},
+		"accents":        {MinLength: 12, MaxLength: 12},
+		"truncate":       {MaxLength: 12, Truncate: true},
+	}
+	for name, opts := range tests {
+		t.Run(name, func(t *testing.T) {
+			limits := opts.limits()
+			phrases, err := db.GetPhrases(ctx, "us", limits)
+			if err != nil {
+				t.Fatal(err)
+			}
+			all, err := db.GetPhrases(ctx, "us", lengthLimits{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The database and the embedded phrases are limited the same.
+			if want := limits.filter(all); len(phrases) != len(want) {
+				t.Errorf("GetPhrases: got %d phrases, want %d", len(phrases), len(want))
+			}
+			if len(phrases) == 0 {
+				t.Error("GetPhrases: no phrases")
+			}
+
+			opts.Language = "us"
+			for range 20 {
+				p, err := client.Random(ctx, opts)
+				if err != nil {
+					t.Fatalf("Random: %v", err)
+				}
+				n := limits.length(p.Phrase)
+				if n < opts.MinLength || n > opts.MaxLength && opts.MaxLength > 0 {
+					t.Fatalf("Random: %q has length %d", p.Phrase, n)
+				}
+			}
+		})
+	}
+
+	for _, opts := range []Options{{MinLength: -1}, {MinLength: 10, MaxLength: 5}, {LengthUnit: "lines"}} {
+		if _, err := client.Random(ctx, opts); err == nil {
+			t.Errorf("Random(%+v): want error", opts)
+		}
+	}
+}
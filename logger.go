@@ -1,28 +0,0 @@
-package main
-
-import (
-	"log/slog"
-	"os"
-	"path"
-	"time"
-)
-
-func NewLogger() *slog.Logger {
-	th := slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-		AddSource: true,
-		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
-			if a.Key == slog.SourceKey {
-				s := a.Value.Any().(*slog.Source)
-				s.File = path.Base(s.File)
-			}
-
-			if a.Key == slog.TimeKey {
-				t := a.Value.Time()
-				a.Value = slog.StringValue(t.Format(time.DateTime))
-			}
-			return a
-		},
-	})
-
-	return slog.New(th)
-}
@@ -0,0 +1,291 @@
+package motivar
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"modernc.org/sqlite"
+)
+
+// DBStats size and rows of a database
+type DBStats struct {
+	Path string
+	// Size in bytes, including the write-ahead log.
+	Size          int64
+	SchemaVersion int
+	Phrases       int
+	Sources       int
+	Translations  int
+}
+
+// schemaVersion is the version of the schema after all migrations, saved in
+// PRAGMA user_version.
+func schemaVersion() (int, error) {
+	dir, err := migrations()
+	return len(dir), err
+}
+
+func (d *database) stats(ctx context.Context, path string) (DBStats, error) {
+	s := DBStats{Path: path}
+
+	err := d.conn.QueryRowContext(ctx, "PRAGMA user_version").Scan(&s.SchemaVersion)
+	if err != nil {
+		return s, err
+	}
+
+	counts := []struct {
+		table string
+		count *int
+		// optional tables are missing in older schemas.
+		optional bool
+	}{
+		{"phrases", &s.Phrases, false},
+		{"hashes", &s.Sources, false},
+		{"translations", &s.Translations, true},
+	}
+	for _, c := range counts {
+		exists, err := d.hasTable(ctx, c.table)
+		if err != nil {
+			return s, err
+		}
+		if !exists {
+			if c.optional {
+				continue
+			}
+			return s, fmt.Errorf("table %s not found", c.table)
+		}
+
+		err = d.conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+c.table).Scan(c.count)
+		if err != nil {
+			return s, fmt.Errorf("counting %s: %w", c.table, err)
+		}
+	}
+
+	for _, suffix := range []string{"", "-wal"} {
+		if info, err := os.Stat(path + suffix); err == nil {
+			s.Size += info.Size()
+		}
+	}
+	return s, nil
+}
+
+func (d *database) hasTable(ctx context.Context, table string) (bool, error) {
+	var n int
+	err := d.conn.QueryRowContext(ctx, "SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", table).Scan(&n)
+	return n > 0, err
+}
+
+// openBackup opens the backup file read-only and without the pragmas of
+// openDatabase, so inspecting it doesn't change it.
+func openBackup(file string) (*database, error) {
+	conn, err := sql.Open("sqlite", backupURI(file))
+	if err != nil {
+		return nil, err
+	}
+	return &database{conn: conn}, nil
+}
+
+// backupURI is the read-only SQLite URI of file.
+func backupURI(file string) string {
+	return "file:" + (&url.URL{Path: file}).EscapedPath() + "?mode=ro"
+}
+
+// Stats returns the size and rows of the database.
+func (c *Client) Stats(ctx context.Context) (DBStats, error) {
+	db, err := c.database(ctx)
+	if err != nil {
+		return DBStats{}, err
+	}
+	return db.stats(ctx, c.dbPath)
+}
+
+// Backup writes a copy of the database to file, which must not exist. The
+// database can be used while the backup is made.
+func (c *Client) Backup(ctx context.Context, file string) (DBStats, error) {
+	if exists(file) {
+		return DBStats{}, fmt.Errorf("%s already exists", file)
+	}
+
+	db, err := c.database(ctx)
+	if err != nil {
+		return DBStats{}, err
+	}
+
+	_, err = db.conn.ExecContext(ctx, "VACUUM INTO ?", file)
+	if err != nil {
+		return DBStats{}, fmt.Errorf("backup: %w", err)
+	}
+
+	backup, err := openBackup(file)
+	if err != nil {
+		return DBStats{}, err
+	}
+	defer backup.Close()
+	return backup.stats(ctx, file)
+}
+
+// Restore replaces the database with the backup file, after checking its
+// integrity and that its schema is known. Older schemas are migrated. The
+// backup is copied by SQLite holding the write lock, so other processes using
+// the database see it before or after the restore, never in between.
+func (c *Client) Restore(ctx context.Context, file string) (DBStats, error) {
+	if !exists(file) {
+		return DBStats{}, fmt.Errorf("%s not found", file)
+	}
+	if err := checkBackup(ctx, file); err != nil {
+		return DBStats{}, err
+	}
+
+	db, err := c.database(ctx)
+	if err != nil {
+		return DBStats{}, err
+	}
+	if err = db.restore(ctx, file); err != nil {
+		return DBStats{}, fmt.Errorf("restoring %s: %w", file, err)
+	}
+	if err = db.RunMigrations(ctx); err != nil {
+		return DBStats{}, err
+	}
+	c.invalidateCounts()
+
+	return c.Stats(ctx)
+}
+
+// checkBackup returns an error if file is corrupted, isn't a motivar
+// database or has a schema newer than this version.
+func checkBackup(ctx context.Context, file string) error {
+	backup, err := openBackup(file)
+	if err != nil {
+		return err
+	}
+	defer backup.Close()
+
+	problems, err := backup.check(ctx)
+	if err != nil {
+		return fmt.Errorf("checking %s: %w", file, err)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s is corrupted: %s", file, problems[0])
+	}
+
+	stats, err := backup.stats(ctx, file)
+	if err != nil {
+		return fmt.Errorf("%s is not a motivar database: %w", file, err)
+	}
+	current, err := schemaVersion()
+	if err != nil {
+		return err
+	}
+	if stats.SchemaVersion > current {
+		return fmt.Errorf("%s has schema version %d, newer than %d of this version", file, stats.SchemaVersion, current)
+	}
+	return nil
+}
+
+// restore copies all pages of the backup file to the database with the
+// backup API of SQLite.
+func (d *database) restore(ctx context.Context, file string) error {
+	conn, err := d.conn.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		restorer, ok := driverConn.(interface {
+			NewRestore(srcURI string) (*sqlite.Backup, error)
+		})
+		if !ok {
+			return errors.New("the SQLite driver doesn't support restoring")
+		}
+
+		backup, err := restorer.NewRestore(backupURI(file))
+		if err != nil {
+			return err
+		}
+		// All pages in one step, holding the write lock until the end.
+		if _, err = backup.Step(-1); err != nil {
+			backup.Finish()
+			return err
+		}
+		return backup.Finish()
+	})
+}
+
+// Check runs the integrity and foreign key checks of SQLite. It returns the
+// problems found, none if the database is fine.
+func (c *Client) Check(ctx context.Context) ([]string, error) {
+	db, err := c.database(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return db.check(ctx)
+}
+
+func (d *database) check(ctx context.Context) ([]string, error) {
+	var problems []string
+
+	rows, err := d.conn.QueryContext(ctx, "PRAGMA integrity_check")
+	if err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		var result string
+		if err = rows.Scan(&result); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		if result != "ok" {
+			problems = append(problems, result)
+		}
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = d.conn.QueryContext(ctx, "PRAGMA foreign_key_check")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			table, parent string
+			rowID         sql.NullInt64
+			fkID          int
+		)
+		if err = rows.Scan(&table, &rowID, &parent, &fkID); err != nil {
+			return nil, err
+		}
+		problems = append(problems, fmt.Sprintf("row %d of %s references a missing row of %s", rowID.Int64, table, parent))
+	}
+	return problems, rows.Err()
+}
+
+// Vacuum rebuilds the database file to reclaim unused space. It returns the
+// size before and after.
+func (c *Client) Vacuum(ctx context.Context) (before, after DBStats, err error) {
+	before, err = c.Stats(ctx)
+	if err != nil {
+		return
+	}
+	db, err := c.database(ctx)
+	if err != nil {
+		return
+	}
+	if _, err = db.conn.ExecContext(ctx, "VACUUM"); err != nil {
+		return
+	}
+	after, err = c.Stats(ctx)
+	return
+}
+
+// DBPath returns the database file of the client.
+func (c *Client) DBPath() string {
+	return c.dbPath
+}
@@ -0,0 +1,133 @@
+package motivar
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	br, us := embeddedPhrases("br")[0], embeddedPhrases("us")[0]
+	if err := client.Link(ctx, br, us); err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "backup.db")
+	stats, err := client.Backup(ctx, file)
+	if err != nil {
+		t.Fatalf("Backup: %v", err)
+	}
+	version, _ := schemaVersion()
+	if stats.Translations != 2 || stats.SchemaVersion != version || stats.Size == 0 {
+		t.Errorf("Backup: got %+v", stats)
+	}
+	if _, err = client.Backup(ctx, file); err == nil {
+		t.Error("Backup: expected error when the file exists")
+	}
+
+	// Inspecting and restoring the backup doesn't change it.
+	before, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = client.Unlink(ctx, br); err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err = client.Restore(ctx, file)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if stats.Translations != 2 {
+		t.Errorf("Restore: got %d translations, want 2", stats.Translations)
+	}
+	if _, err = client.Translation(ctx, br, "us"); err != nil {
+		t.Errorf("Translation after Restore: %v", err)
+	}
+
+	problems, err := client.Check(ctx)
+	if err != nil || len(problems) > 0 {
+		t.Errorf("Check: got %v, %v", problems, err)
+	}
+
+	after, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Error("Restore: the backup file changed")
+	}
+}
+
+func TestRestoreOlderSchema(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	// A backup of the first version, without translations.
+	file := filepath.Join(t.TempDir(), "old.db")
+	old, err := sql.Open("sqlite", file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := embedContent.ReadFile("migrations/0-create-tables.sql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = old.Exec(string(first) + `;
+		INSERT INTO hashes (url, content_hash) VALUES ('old.csv', 'abc');
+		INSERT INTO phrases (author, phrase, phrase_hash, language, hash_id) VALUES ('Old', 'An old phrase.', 'def', 'us', 1);
+		PRAGMA user_version = 1`)
+	old.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stats, err := client.Restore(ctx, file)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	version, _ := schemaVersion()
+	if stats.SchemaVersion != version || stats.Phrases != 1 || stats.Translations != 0 {
+		t.Errorf("Restore: got %+v", stats)
+	}
+}
+
+func TestRestoreRejectsInvalidBackups(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	dir := t.TempDir()
+
+	garbage := filepath.Join(dir, "garbage.db")
+	if err := os.WriteFile(garbage, []byte("not a database"), 0664); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Restore(ctx, garbage); err == nil {
+		t.Error("Restore: expected error for a file that is not a database")
+	}
+
+	newer := filepath.Join(dir, "newer.db")
+	if _, err := client.Backup(ctx, newer); err != nil {
+		t.Fatal(err)
+	}
+	db, err := openDatabase(newer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.conn.Exec("PRAGMA user_version = 999")
+	db.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = client.Restore(ctx, newer); err == nil || !strings.Contains(err.Error(), "schema version 999") {
+		t.Errorf("Restore: got %v, want error about the schema version", err)
+	}
+}
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS translations
+(
+    phrase_hash TEXT PRIMARY KEY,
+    group_id    INTEGER NOT NULL,
+    language    TEXT NOT NULL,
+    created_at  DATETIME
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS translations_group_language ON translations (group_id, language);
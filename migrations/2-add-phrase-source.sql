@@ -0,0 +1 @@
+ALTER TABLE phrases ADD COLUMN source TEXT NOT NULL DEFAULT '';
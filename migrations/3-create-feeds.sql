@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS feeds
+(
+    url        TEXT PRIMARY KEY,
+    last_item  TEXT NOT NULL,
+    updated_at DATETIME
+);
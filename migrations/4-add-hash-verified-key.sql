@@ -0,0 +1 @@
+ALTER TABLE hashes ADD COLUMN verified_key TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,514 @@
+//go:generate go run data/data_generate.go
+
+// Package motivar gives motivational phrases from the embedded corpus and
+// from the phrases imported into a local SQLite database.
+//
+//	client, err := motivar.New(motivar.WithLanguage("us"))
+//	if err != nil {
+//		return err
+//	}
+//	defer client.Close()
+//
+//	phrase, err := client.Random(ctx, motivar.Options{})
+package motivar
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wvoliveira/motivar/data"
+)
+
+// Phrase a phrase and its author
+type Phrase struct {
+	// ID identifies the phrase. Embedded phrases have an ID derived from
+	// their text, so it doesn't change between versions.
+	ID       int64  `json:"id"`
+	Author   string `json:"author"`
+	Phrase   string `json:"phrase"`
+	Language string `json:"language"`
+	// Source is where the phrase was taken from, like a book title.
+	Source string `json:"source,omitempty"`
+}
+
+// Options to select phrases
+type Options struct {
+	// Language of the phrases. Empty uses the client language.
+	Language string
+	// MinLength and MaxLength limit the length of the phrases, in
+	// LengthUnit. Zero is no limit.
+	MinLength int
+	MaxLength int
+	// LengthUnit is LengthCharacters, the default, or LengthWords.
+	LengthUnit string
+	// Truncate shortens the phrases longer than MaxLength, see Truncate,
+	// instead of skipping them.
+	Truncate bool
+}
+
+// ListOptions to list phrases
+type ListOptions struct {
+	Options
+	// Author filters phrases whose author contains it, ignoring case.
+	Author string
+	// Offset is how many phrases to skip.
+	Offset int
+	// Limit is the max number of phrases returned. Zero means no limit.
+	Limit int
+}
+
+// safeAttempts is how many random database phrases are tried in safe mode.
+const safeAttempts = 10
+
+// LanguageAuto as Source language detects the language of each phrase.
+const LanguageAuto = "auto"
+
+// Source where to import phrases from
+type Source struct {
+	// URL of the file with phrases.
+	URL string
+	// Format of the file, one of Formats, or FormatAuto to detect it.
+	Format string
+	// Language of the phrases, or LanguageAuto to detect it per phrase.
+	Language string
+	// AuthorSeparator splits the phrase from the author in txt files.
+	// Empty tries DefaultAuthorSeparators.
+	AuthorSeparator string
+	// Patterns extract the phrase and the author of the items of feeds,
+	// with the groups (?P<phrase>...) and (?P<author>...). Empty uses
+	// DefaultFeedPatterns.
+	Patterns []string
+	// SHA256 pins the hex SHA-256 digest of the fetched content. Empty
+	// doesn't check it.
+	SHA256 string
+	// SignatureURL is the minisign detached signature of the content, that
+	// must be made by one of TrustedKeys. Empty doesn't check it.
+	SignatureURL string
+	// TrustedKeys are the keys that can sign the content.
+	TrustedKeys []PublicKey
+}
+
+// ErrNotFound is returned when a phrase doesn't exist.
+var ErrNotFound = errors.New("phrase not found")
+
+// Client gives phrases from the embedded corpus and the database
+type Client struct {
+	dbPath     string
+	language   string
+	httpClient *http.Client
+	logger     *slog.Logger
+	// filter blocks unsafe phrases in safe mode, see WithContentFilter.
+	filter *contentFilter
+
+	// db is opened on first use, see database.
+	mu sync.Mutex
+	db *database
+	// storageErr is the last error of the database while reading phrases,
+	// when only the embedded phrases were used.
+	storageErr error
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithDBPath sets the SQLite database file. Default is DefaultDBPath.
+func WithDBPath(path string) Option {
+	return func(c *Client) {
+		c.dbPath = path
+	}
+}
+
+// WithLanguage sets the default language of the phrases. Default is "br".
+func WithLanguage(language string) Option {
+	return func(c *Client) {
+		c.language = language
+	}
+}
+
+// WithHTTPClient sets the HTTP client used by Import. Default is http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = client
+	}
+}
+
+// WithLogger sets the logger of the import progress. Default discards logs.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// DefaultDBPath returns the default location of the database, as resolved
+// by ResolvePaths.
+func DefaultDBPath() (string, error) {
+	p, err := ResolvePaths("", "")
+	if err != nil {
+		return "", err
+	}
+	return p.DB, nil
+}
+
+// New returns a Client. The database is opened, and migrated, only when
+// needed: phrases from the embedded corpus don't touch it.
+func New(opts ...Option) (*Client, error) {
+	c := &Client{
+		language:   "br",
+		httpClient: http.DefaultClient,
+		logger:     slog.New(slog.DiscardHandler),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if err := CheckLanguage(c.language); err != nil {
+		return nil, err
+	}
+
+	if c.dbPath == "" {
+		path, err := DefaultDBPath()
+		if err != nil {
+			// Without a database the embedded phrases can still be used.
+			c.storageErr = fmt.Errorf("finding database: %w", err)
+		}
+		c.dbPath = path
+	}
+	return c, nil
+}
+
+// database opens the database and applies the migrations on first use.
+func (c *Client) database(ctx context.Context) (*database, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.db != nil {
+		return c.db, nil
+	}
+	if c.dbPath == "" {
+		return nil, c.storageErr
+	}
+
+	db, err := openDatabase(c.dbPath)
+	if err != nil {
+		return nil, fmt.Errorf("opening database: %w", err)
+	}
+
+	if err = db.ConnectAndTest(ctx); err == nil {
+		err = db.RunMigrations(ctx)
+	}
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("opening database %s: %w", c.dbPath, err)
+	}
+
+	c.db = db
+	return db, nil
+}
+
+// StorageError returns the last error of the database while reading phrases,
+// or nil. When the database fails, Random, RandomTranslated, Today, Search
+// and List use only the embedded phrases instead of failing.
+func (c *Client) StorageError() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.storageErr
+}
+
+// degrade saves err of the database, which was replaced by the embedded
+// phrases.
+func (c *Client) degrade(err error) {
+	c.logger.Debug(fmt.Sprintf("Using only embedded phrases: %v", err))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.storageErr = err
+}
+
+// Close closes the database, if it was opened.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.db == nil {
+		return nil
+	}
+	err := c.db.Close()
+	c.db = nil
+	return err
+}
+
+// Languages returns the supported languages.
+func Languages() []string {
+	return slices.Clone(data.LanguageCodes)
+}
+
+// CheckLanguage returns an error if language is not supported.
+func CheckLanguage(language string) error {
+	if slices.Contains(data.LanguageCodes, language) {
+		return nil
+	}
+	return fmt.Errorf("language %q not supported. Use %s", language, strings.Join(data.LanguageCodes, ", "))
+}
+
+// languageOf returns the language of opts, and an error if opts are invalid.
+func (c *Client) languageOf(opts Options) (string, error) {
+	language := opts.Language
+	if language == "" {
+		language = c.language
+	}
+	if err := opts.checkLength(); err != nil {
+		return language, err
+	}
+	return language, CheckLanguage(language)
+}
+
+// Random returns a random phrase, from the database or the embedded corpus.
+func (c *Client) Random(ctx context.Context, opts Options) (Phrase, error) {
+	language, err := c.languageOf(opts)
+	if err != nil {
+		return Phrase{}, err
+	}
+
+	if rand.Intn(2) == 1 {
+		if phrase, ok := c.randomSafe(ctx, language, opts); ok {
+			return phrase, nil
+		}
+	}
+
+	phrases := c.safe(opts.limits().filter(embeddedPhrases(language)))
+	if len(phrases) == 0 {
+		// With narrow length limits, only database phrases may fit.
+		if phrase, ok := c.randomSafe(ctx, language, opts); ok {
+			return phrase, nil
+		}
+		return Phrase{}, ErrNotFound
+	}
+	return opts.truncate(phrases[rand.Intn(len(phrases))]), nil
+}
+
+// randomSafe returns a random database phrase of language matching opts and
+// not blocked by the content filter. In safe mode, a few blocked phrases in a
+// row fall back to the embedded phrases.
+func (c *Client) randomSafe(ctx context.Context, language string, opts Options) (Phrase, bool) {
+	for range safeAttempts {
+		phrase, err := c.randomFromDatabase(ctx, language, opts.limits())
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			c.degrade(err)
+		}
+		if phrase.Phrase == "" {
+			return Phrase{}, false
+		}
+		if !c.unsafe(phrase) {
+			return opts.truncate(phrase), true
+		}
+	}
+	return Phrase{}, false
+}
+
+// Today returns the phrase of the day. It's the same during the whole day
+// while no phrases are imported.
+func (c *Client) Today(ctx context.Context, opts Options) (Phrase, error) {
+	phrases, err := c.allOrEmbedded(ctx, opts)
+	if err != nil {
+		return Phrase{}, err
+	}
+	if len(phrases) == 0 {
+		return Phrase{}, ErrNotFound
+	}
+
+	day := sha256.Sum256([]byte(time.Now().Format(time.DateOnly)))
+	index := binary.BigEndian.Uint64(day[:8]) % uint64(len(phrases))
+	return opts.truncate(phrases[index]), nil
+}
+
+// Search returns the phrases whose text or author contains query, ignoring case.
+func (c *Client) Search(ctx context.Context, query string, opts Options) ([]Phrase, error) {
+	language, err := c.languageOf(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	lower := strings.ToLower(query)
+	var phrases []Phrase
+	for _, p := range opts.limits().filter(embeddedPhrases(language)) {
+		if strings.Contains(strings.ToLower(p.Phrase), lower) || strings.Contains(strings.ToLower(p.Author), lower) {
+			phrases = append(phrases, p)
+		}
+	}
+
+	db, err := c.database(ctx)
+	if err == nil {
+		var dbPhrases []Phrase
+		dbPhrases, err = db.SearchPhrases(ctx, language, query, opts.limits())
+		phrases = append(phrases, dbPhrases...)
+	}
+	if err != nil {
+		c.degrade(err)
+	}
+	return opts.truncateAll(c.safe(phrases)), nil
+}
+
+// List returns a page of phrases matching opts and the total of phrases
+// matching opts.
+func (c *Client) List(ctx context.Context, opts ListOptions) ([]Phrase, int, error) {
+	if opts.Offset < 0 || opts.Limit < 0 {
+		return nil, 0, errors.New("offset and limit must not be negative")
+	}
+
+	phrases, err := c.allOrEmbedded(ctx, opts.Options)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if opts.Author != "" {
+		author := strings.ToLower(opts.Author)
+		phrases = slices.DeleteFunc(phrases, func(p Phrase) bool {
+			return !strings.Contains(strings.ToLower(p.Author), author)
+		})
+	}
+
+	total := len(phrases)
+	start := min(opts.Offset, total)
+	end := total
+	if opts.Limit > 0 {
+		end = min(start+opts.Limit, total)
+	}
+	return opts.truncateAll(phrases[start:end]), total, nil
+}
+
+// Get returns the phrase with id, in any language.
+func (c *Client) Get(ctx context.Context, id int64) (Phrase, error) {
+	for _, language := range data.LanguageCodes {
+		for _, p := range embeddedPhrases(language) {
+			if p.ID == id {
+				return p, nil
+			}
+		}
+	}
+
+	db, err := c.database(ctx)
+	if err != nil {
+		return Phrase{}, err
+	}
+
+	phrase, err := db.GetPhrase(ctx, id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return phrase, ErrNotFound
+	}
+	return phrase, err
+}
+
+// Import fetches the phrases of src and saves them in the database. It
+// returns how many new phrases were saved.
+func (c *Client) Import(ctx context.Context, src Source) (int, error) {
+	if src.Language != LanguageAuto {
+		if err := CheckLanguage(src.Language); err != nil {
+			return 0, err
+		}
+	}
+	if src.Format != FormatAuto {
+		if err := CheckFormat(src.Format); err != nil {
+			return 0, err
+		}
+	}
+	if _, err := compileFeedPatterns(src.Patterns); err != nil {
+		return 0, err
+	}
+	if src.SHA256 != "" && !sha256Digest.MatchString(src.SHA256) {
+		return 0, fmt.Errorf("invalid SHA-256 digest %q: must be 64 hex characters", src.SHA256)
+	}
+	return c.fetchAndSave(ctx, src)
+}
+
+// Export writes all phrases in one of ExportFormats. The output can be
+// imported again.
+func (c *Client) Export(ctx context.Context, w io.Writer, format string, opts Options) error {
+	f := lookupFormat(format)
+	if f == nil || f.write == nil {
+		return fmt.Errorf("format %q can't be exported. Use %s", format, strings.Join(ExportFormats(), ", "))
+	}
+
+	phrases, err := c.all(ctx, opts)
+	if err != nil {
+		return err
+	}
+	return f.write(w, phrases)
+}
+
+// CheckFormat check format supported
+func CheckFormat(format string) error {
+	if lookupFormat(format) != nil {
+		return nil
+	}
+	return fmt.Errorf("format %q not supported. Use %s", format, strings.Join(Formats(), ", "))
+}
+
+// all returns the embedded phrases followed by the database phrases.
+func (c *Client) all(ctx context.Context, opts Options) ([]Phrase, error) {
+	language, err := c.languageOf(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := c.database(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	dbPhrases, err := db.GetPhrases(ctx, language, opts.limits())
+	if err != nil {
+		return nil, err
+	}
+	return append(opts.limits().filter(embeddedPhrases(language)), dbPhrases...), nil
+}
+
+// allOrEmbedded is like all, but returns only the embedded phrases when the
+// database fails.
+func (c *Client) allOrEmbedded(ctx context.Context, opts Options) ([]Phrase, error) {
+	language, err := c.languageOf(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	phrases, err := c.all(ctx, opts)
+	if err != nil {
+		c.degrade(err)
+		return c.safe(opts.limits().filter(embeddedPhrases(language))), nil
+	}
+	return c.safe(phrases), nil
+}
+
+func embeddedPhrases(language string) []Phrase {
+	items := data.Languages[language]
+
+	phrases := make([]Phrase, len(items))
+	for i, p := range items {
+		phrases[i] = Phrase{
+			ID:       embeddedID(p.Language, p.Phrase),
+			Author:   p.Author,
+			Phrase:   p.Phrase,
+			Language: p.Language,
+		}
+	}
+	return phrases
+}
+
+// embeddedID derives a positive ID from the language and the text of an
+// embedded phrase.
+func embeddedID(language, phrase string) int64 {
+	hash := sha256.Sum256([]byte(language + "\x00" + phrase))
+	return int64(binary.BigEndian.Uint64(hash[:8]) & math.MaxInt64)
+}
@@ -0,0 +1,160 @@
+package motivar
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestClient(t *testing.T, opts ...Option) *Client {
+	t.Helper()
+
+	opts = append([]Option{WithDBPath(filepath.Join(t.TempDir(), "database.db"))}, opts...)
+	client, err := New(opts...)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestImportAndExport(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("samples")))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := newTestClient(t, WithHTTPClient(server.Client()))
+
+	inserted, err := client.Import(ctx, Source{URL: server.URL + "/quotes-br.json", Format: "json", Language: "br"})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if inserted == 0 {
+		t.Fatal("Import: no phrases inserted")
+	}
+
+	_, err = client.Import(ctx, Source{URL: server.URL + "/quotes-br.json", Format: "json", Language: "br"})
+	if !errors.Is(err, ErrContentExists) {
+		t.Errorf("Import twice: got %v, want %v", err, ErrContentExists)
+	}
+
+	found, err := client.Search(ctx, "Duke Ellington", Options{})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(found) == 0 {
+		t.Error("Search: imported phrase not found")
+	}
+
+	var buf bytes.Buffer
+	if err = client.Export(ctx, &buf, "csv", Options{}); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if !bytes.Contains(buf.Bytes(), []byte("Duke Ellington")) {
+		t.Error("Export: imported phrase not exported")
+	}
+}
+
+func TestRandomAndToday(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t, WithLanguage("us"))
+
+	phrase, err := client.Random(ctx, Options{})
+	if err != nil {
+		t.Fatalf("Random: %v", err)
+	}
+	if phrase.Phrase == "" || phrase.Language != "us" {
+		t.Errorf("Random: got %+v", phrase)
+	}
+
+	first, err := client.Today(ctx, Options{Language: "br"})
+	if err != nil {
+		t.Fatalf("Today: %v", err)
+	}
+	second, _ := client.Today(ctx, Options{Language: "br"})
+	if first != second {
+		t.Errorf("Today: got %+v and %+v in the same day", first, second)
+	}
+
+	if _, err = client.Random(ctx, Options{Language: "fr"}); err == nil {
+		t.Error("Random: expected error for unsupported language")
+	}
+}
+
+func TestImportLanguageAuto(t *testing.T) {
+	body := "author,phrase\n" +
+		"Winston Churchill,A coragem é ir de fracasso em fracasso sem perder o ânimo.\n" +
+		"Winston Churchill,Courage is going from failure to failure without losing heart.\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	if _, err := client.Import(ctx, Source{URL: server.URL, Format: "csv", Language: LanguageAuto}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	for _, lang := range []string{"br", "us"} {
+		phrases, err := client.Search(ctx, "Churchill", Options{Language: lang})
+		if err != nil {
+			t.Fatalf("Search: %v", err)
+		}
+		prefix := map[string]string{"br": "A coragem é ir", "us": "Courage is going"}[lang]
+
+		var imported int
+		for _, p := range phrases {
+			if strings.HasPrefix(p.Phrase, prefix) {
+				imported++
+			}
+		}
+		if imported != 1 {
+			t.Errorf("language %q: got %d imported phrases, want 1", lang, imported)
+		}
+	}
+}
+
+func TestDatabaseUnavailable(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "database.db")
+	if err := os.WriteFile(path, []byte("not a database"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	client := newTestClient(t, WithDBPath(path), WithLanguage("us"))
+
+	for range 10 {
+		if _, err := client.Random(ctx, Options{}); err != nil {
+			t.Fatalf("Random: %v", err)
+		}
+	}
+	if _, err := client.Today(ctx, Options{}); err != nil {
+		t.Fatalf("Today: %v", err)
+	}
+	if _, err := client.Search(ctx, "a", Options{}); err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	phrases, total, err := client.List(ctx, ListOptions{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if total != len(embeddedPhrases("us")) || len(phrases) != total {
+		t.Errorf("List = %d phrases, want the %d embedded", total, len(embeddedPhrases("us")))
+	}
+
+	if client.StorageError() == nil {
+		t.Error("StorageError = nil, want the error opening the database")
+	}
+	if _, err := client.Import(ctx, Source{URL: "http://localhost/x.csv", Format: "csv", Language: "us"}); err == nil {
+		t.Error("Import: want error")
+	}
+}
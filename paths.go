@@ -0,0 +1,174 @@
+package motivar
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// Paths where motivar keeps its files
+type Paths struct {
+	// ConfigDir has the ConfigFile, motivar.ini.
+	ConfigDir  string
+	ConfigFile string
+	// DataDir has the database, unless DB is set elsewhere.
+	DataDir string
+	// StateDir has files that change while using motivar, like logs.
+	StateDir string
+	DB       string
+	// Home is set when all files are in one folder (-home or MOTIVAR_HOME).
+	Home string
+}
+
+const (
+	appName        = "motivar"
+	configFileName = "motivar.ini"
+	dbFileName     = "database.db"
+)
+
+// ResolvePaths returns where the files are. If home is not empty, or else
+// MOTIVAR_HOME, everything is inside it like the old ~/.motivar folder:
+// motivar.ini, data/ and state/. Otherwise the XDG base directories are used:
+// $XDG_CONFIG_HOME/motivar, $XDG_DATA_HOME/motivar and $XDG_STATE_HOME/motivar.
+// The database is db, or else MOTIVAR_DB, or database.db in the data folder.
+func ResolvePaths(home, db string) (Paths, error) {
+	userHome, err := homedir.Dir()
+	if err != nil {
+		return Paths{}, err
+	}
+	return resolvePaths(home, db, os.Getenv, userHome), nil
+}
+
+func resolvePaths(home, db string, getenv func(string) string, userHome string) Paths {
+	if home == "" {
+		home = getenv("MOTIVAR_HOME")
+	}
+	if db == "" {
+		db = getenv("MOTIVAR_DB")
+	}
+
+	var p Paths
+	if home != "" {
+		p = Paths{
+			ConfigDir: home,
+			DataDir:   filepath.Join(home, "data"),
+			StateDir:  filepath.Join(home, "state"),
+			Home:      home,
+		}
+	} else {
+		p = Paths{
+			ConfigDir: filepath.Join(xdgDir(getenv, "XDG_CONFIG_HOME", userHome, ".config"), appName),
+			DataDir:   filepath.Join(xdgDir(getenv, "XDG_DATA_HOME", userHome, ".local", "share"), appName),
+			StateDir:  filepath.Join(xdgDir(getenv, "XDG_STATE_HOME", userHome, ".local", "state"), appName),
+		}
+	}
+
+	p.ConfigFile = filepath.Join(p.ConfigDir, configFileName)
+	p.DB = db
+	if p.DB == "" {
+		p.DB = filepath.Join(p.DataDir, dbFileName)
+	}
+	return p
+}
+
+// xdgDir returns the value of the variable name, or the default inside
+// userHome. Relative values are invalid by the specification and ignored.
+func xdgDir(getenv func(string) string, name, userHome string, def ...string) string {
+	if dir := getenv(name); filepath.IsAbs(dir) {
+		return dir
+	}
+	return filepath.Join(append([]string{userHome}, def...)...)
+}
+
+// LegacyHome returns the folder used by older versions, ~/.motivar.
+func LegacyHome() (string, error) {
+	userHome, err := homedir.Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(userHome, "."+appName), nil
+}
+
+// MigrateLegacyHome moves the conf file and the database of legacy to p, when
+// p uses the XDG folders, each one only if p has none yet. It returns whether
+// files were moved.
+//
+// The database goes first, after its -wal and -shm files, so a failure never
+// leaves the database without its committed changes, and the conf file last,
+// as its move alone doesn't stop the next run from moving the database.
+func MigrateLegacyHome(legacy string, p Paths) (bool, error) {
+	if p.Home != "" {
+		return false, nil
+	}
+	if info, err := os.Stat(legacy); err != nil || !info.IsDir() {
+		return false, nil
+	}
+
+	type move struct{ from, to string }
+	var moves []move
+	// A database set with -db or MOTIVAR_DB is not replaced.
+	legacyDB := filepath.Join(legacy, "data", dbFileName)
+	if p.DB == filepath.Join(p.DataDir, dbFileName) && exists(legacyDB) && !exists(p.DB) {
+		for _, suffix := range []string{"-wal", "-shm", ""} {
+			moves = append(moves, move{legacyDB + suffix, p.DB + suffix})
+		}
+	}
+	if !exists(p.ConfigFile) {
+		moves = append(moves, move{filepath.Join(legacy, configFileName), p.ConfigFile})
+	}
+
+	var moved bool
+	for _, m := range moves {
+		if !exists(m.from) {
+			continue
+		}
+		// Stops before the database when its -wal can't be moved.
+		if err := moveFile(m.from, m.to); err != nil {
+			return moved, err
+		}
+		moved = true
+	}
+
+	// Only removed when empty.
+	_ = os.Remove(filepath.Join(legacy, "data"))
+	_ = os.Remove(legacy)
+	return moved, nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, fs.ErrNotExist)
+}
+
+// moveFile renames from to to, copying when they are in different devices.
+func moveFile(from, to string) error {
+	if err := os.MkdirAll(filepath.Dir(to), 0764); err != nil {
+		return err
+	}
+	if err := os.Rename(from, to); err == nil {
+		return nil
+	}
+
+	src, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0664)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(dst, src); err != nil {
+		dst.Close()
+		return err
+	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+	return os.Remove(from)
+}
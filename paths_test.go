@@ -0,0 +1,142 @@
+package motivar
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestResolvePaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		home, db string
+		env      map[string]string
+		want     Paths
+	}{
+		{
+			name: "xdg defaults",
+			want: Paths{
+				ConfigDir:  "/u/.config/motivar",
+				ConfigFile: "/u/.config/motivar/motivar.ini",
+				DataDir:    "/u/.local/share/motivar",
+				StateDir:   "/u/.local/state/motivar",
+				DB:         "/u/.local/share/motivar/database.db",
+			},
+		},
+		{
+			name: "xdg variables",
+			env:  map[string]string{"XDG_CONFIG_HOME": "/c", "XDG_DATA_HOME": "/d", "XDG_STATE_HOME": "relative"},
+			want: Paths{
+				ConfigDir:  "/c/motivar",
+				ConfigFile: "/c/motivar/motivar.ini",
+				DataDir:    "/d/motivar",
+				StateDir:   "/u/.local/state/motivar",
+				DB:         "/d/motivar/database.db",
+			},
+		},
+		{
+			name: "home flag wins over environment",
+			home: "/h",
+			env:  map[string]string{"MOTIVAR_HOME": "/env", "MOTIVAR_DB": "/env.db"},
+			want: Paths{
+				ConfigDir:  "/h",
+				ConfigFile: "/h/motivar.ini",
+				DataDir:    "/h/data",
+				StateDir:   "/h/state",
+				DB:         "/env.db",
+				Home:       "/h",
+			},
+		},
+		{
+			name: "db flag",
+			db:   "/tmp/test.db",
+			env:  map[string]string{"MOTIVAR_HOME": "/env"},
+			want: Paths{
+				ConfigDir:  "/env",
+				ConfigFile: "/env/motivar.ini",
+				DataDir:    "/env/data",
+				StateDir:   "/env/state",
+				DB:         "/tmp/test.db",
+				Home:       "/env",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		getenv := func(name string) string { return tt.env[name] }
+		if got := resolvePaths(tt.home, tt.db, getenv, "/u"); got != tt.want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestMigrateLegacyHome(t *testing.T) {
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, ".motivar")
+
+	files := map[string]string{
+		filepath.Join(legacy, "motivar.ini"):         "language = us\n",
+		filepath.Join(legacy, "data", "database.db"): "sqlite",
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(name), 0764); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	getenv := func(string) string { return "" }
+	p := resolvePaths("", "", getenv, dir)
+
+	moved, err := MigrateLegacyHome(legacy, p)
+	if err != nil || !moved {
+		t.Fatalf("MigrateLegacyHome: got %v, %v", moved, err)
+	}
+
+	if content, err := os.ReadFile(p.ConfigFile); err != nil || string(content) != "language = us\n" {
+		t.Errorf("conf file: got %q, %v", content, err)
+	}
+	if content, err := os.ReadFile(p.DB); err != nil || string(content) != "sqlite" {
+		t.Errorf("database: got %q, %v", content, err)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Errorf("legacy folder not removed: %v", err)
+	}
+
+	if moved, _ = MigrateLegacyHome(legacy, p); moved {
+		t.Error("MigrateLegacyHome: moved files twice")
+	}
+}
+
+func TestMigrateLegacyHomePartial(t *testing.T) {
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, ".motivar")
+	p := resolvePaths("", "", func(string) string { return "" }, dir)
+
+	// The conf file was moved by a run that failed to move the database.
+	files := map[string]string{
+		p.ConfigFile: "language = us\n",
+		filepath.Join(legacy, "data", "database.db"):     "sqlite",
+		filepath.Join(legacy, "data", "database.db-wal"): "wal",
+	}
+	for name, content := range files {
+		if err := os.MkdirAll(filepath.Dir(name), 0764); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(name, []byte(content), 0664); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	moved, err := MigrateLegacyHome(legacy, p)
+	if err != nil || !moved {
+		t.Fatalf("MigrateLegacyHome: got %v, %v", moved, err)
+	}
+	for file, want := range map[string]string{p.DB: "sqlite", p.DB + "-wal": "wal"} {
+		if content, err := os.ReadFile(file); err != nil || string(content) != want {
+			t.Errorf("%s: got %q, %v", file, content, err)
+		}
+	}
+}
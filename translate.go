@@ -0,0 +1,248 @@
+package motivar
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// Translations link phrases with the same meaning in different languages.
+// Linked phrases belong to the same group, with at most one phrase per
+// language. Phrases are referenced by the hash of their text, so embedded and
+// database phrases can be linked.
+
+var sha256Hex = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Link puts a and b in the same translation group.
+func (c *Client) Link(ctx context.Context, a, b Phrase) error {
+	if a.Language == b.Language {
+		return fmt.Errorf("phrases have the same language %q", a.Language)
+	}
+
+	db, err := c.database(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = linkTranslation(ctx, tx, a, b); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Unlink removes p from its translation group.
+func (c *Client) Unlink(ctx context.Context, p Phrase) error {
+	hash := generateHash(p.Phrase)
+
+	db, err := c.database(ctx)
+	if err != nil {
+		return err
+	}
+
+	tx, err := db.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	groupID, err := translationGroup(ctx, tx, hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctx, "DELETE FROM translations WHERE phrase_hash = ?", hash); err != nil {
+		return err
+	}
+
+	// A group with only one phrase doesn't link anything.
+	_, err = tx.ExecContext(ctx, `DELETE FROM translations WHERE group_id = ?
+		AND (SELECT COUNT(*) FROM translations WHERE group_id = ?) < 2`, groupID, groupID)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Translation returns the translation of p to language.
+func (c *Client) Translation(ctx context.Context, p Phrase, language string) (Phrase, error) {
+	db, err := c.database(ctx)
+	if err != nil {
+		return Phrase{}, err
+	}
+
+	row := db.conn.QueryRowContext(ctx, `SELECT b.phrase_hash FROM translations a
+		JOIN translations b ON a.group_id = b.group_id
+		WHERE a.phrase_hash = ? AND b.language = ?`, generateHash(p.Phrase), language)
+
+	var hash string
+	err = row.Scan(&hash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Phrase{}, ErrNotFound
+	}
+	if err != nil {
+		return Phrase{}, err
+	}
+	return c.byHash(ctx, hash)
+}
+
+// RandomTranslated returns a random phrase of opts language and its
+// translation to language. If there are no linked phrases between both
+// languages, it returns a random phrase and ErrNotFound.
+func (c *Client) RandomTranslated(ctx context.Context, opts Options, language string) (Phrase, Phrase, error) {
+	from, err := c.languageOf(opts)
+	if err != nil {
+		return Phrase{}, Phrase{}, err
+	}
+	if err = CheckLanguage(language); err != nil {
+		return Phrase{}, Phrase{}, err
+	}
+
+	var hashA, hashB string
+	db, err := c.database(ctx)
+	if err == nil {
+		row := db.conn.QueryRowContext(ctx, `SELECT a.phrase_hash, b.phrase_hash FROM translations a
+			JOIN translations b ON a.group_id = b.group_id
+			WHERE a.language = ? AND b.language = ? ORDER BY RANDOM() LIMIT 1`, from, language)
+		err = row.Scan(&hashA, &hashB)
+	}
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		// Links are only in the database.
+		c.degrade(err)
+		err = sql.ErrNoRows
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		phrase, err := c.Random(ctx, Options{Language: from})
+		if err != nil {
+			return Phrase{}, Phrase{}, err
+		}
+		return phrase, Phrase{}, ErrNotFound
+	}
+
+	phrase, err := c.byHash(ctx, hashA)
+	if err != nil {
+		return Phrase{}, Phrase{}, err
+	}
+	translation, err := c.byHash(ctx, hashB)
+	if err == nil && (c.unsafe(phrase) || c.unsafe(translation)) {
+		phrase, err := c.Random(ctx, Options{Language: from})
+		if err != nil {
+			return Phrase{}, Phrase{}, err
+		}
+		return phrase, Phrase{}, ErrNotFound
+	}
+	return phrase, translation, err
+}
+
+// byHash returns the embedded or database phrase with the hash of its text,
+// or the phrase of the text itself.
+func (c *Client) byHash(ctx context.Context, hash string) (Phrase, error) {
+	for _, language := range Languages() {
+		for _, p := range embeddedPhrases(language) {
+			if generateHash(p.Phrase) == hash {
+				return p, nil
+			}
+		}
+	}
+
+	db, err := c.database(ctx)
+	if err != nil {
+		return Phrase{}, err
+	}
+
+	row := db.conn.QueryRowContext(ctx, "SELECT "+phraseColumns+" FROM phrases WHERE phrase_hash = ?", hash)
+
+	var phrase Phrase
+	err = row.Scan(&phrase.ID, &phrase.Phrase, &phrase.Author, &phrase.Language, &phrase.Source)
+	if errors.Is(err, sql.ErrNoRows) {
+		return phrase, ErrNotFound
+	}
+	return phrase, err
+}
+
+// linkImported links the imported phrases with the phrases they are a
+// translation of. Originals not found are skipped.
+func (c *Client) linkImported(ctx context.Context, phrases []databasePhrase) error {
+	for _, item := range phrases {
+		if item.TranslationOf == "" {
+			continue
+		}
+
+		hash := item.TranslationOf
+		if !sha256Hex.MatchString(hash) {
+			hash = generateHash(hash)
+		}
+
+		original, err := c.byHash(ctx, hash)
+		if errors.Is(err, ErrNotFound) {
+			c.logger.Warn(fmt.Sprintf("Translation of %q not found, not linking: %q", item.TranslationOf, item.Phrase))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		phrase := Phrase{Author: item.Author, Phrase: item.Phrase, Language: item.Language}
+		if err = c.Link(ctx, original, phrase); err != nil {
+			c.logger.Warn(fmt.Sprintf("Linking %q: %v", item.Phrase, err))
+		}
+	}
+	return nil
+}
+
+func translationGroup(ctx context.Context, tx *sql.Tx, hash string) (int64, error) {
+	var groupID int64
+	err := tx.QueryRowContext(ctx, "SELECT group_id FROM translations WHERE phrase_hash = ?", hash).Scan(&groupID)
+	return groupID, err
+}
+
+func linkTranslation(ctx context.Context, tx *sql.Tx, a, b Phrase) error {
+	hashA, hashB := generateHash(a.Phrase), generateHash(b.Phrase)
+
+	groupA, errA := translationGroup(ctx, tx, hashA)
+	if errA != nil && !errors.Is(errA, sql.ErrNoRows) {
+		return errA
+	}
+	groupB, errB := translationGroup(ctx, tx, hashB)
+	if errB != nil && !errors.Is(errB, sql.ErrNoRows) {
+		return errB
+	}
+
+	now := time.Now()
+	insert := "INSERT INTO translations (phrase_hash, group_id, language, created_at) VALUES (?, ?, ?, ?)"
+
+	var err error
+	switch {
+	case errA == nil && errB == nil:
+		if groupA == groupB {
+			return nil
+		}
+		// Merge the group of b into the group of a.
+		_, err = tx.ExecContext(ctx, "UPDATE translations SET group_id = ? WHERE group_id = ?", groupA, groupB)
+	case errA == nil:
+		_, err = tx.ExecContext(ctx, insert, hashB, groupA, b.Language, now)
+	case errB == nil:
+		_, err = tx.ExecContext(ctx, insert, hashA, groupB, a.Language, now)
+	default:
+		groupID := generateHashTimestamp()
+		if _, err = tx.ExecContext(ctx, insert, hashA, groupID, a.Language, now); err == nil {
+			_, err = tx.ExecContext(ctx, insert, hashB, groupID, b.Language, now)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("linking translations (only one phrase per language is allowed): %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package motivar
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTranslations(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	br := embeddedPhrases("br")[0]
+	us := embeddedPhrases("us")[0]
+
+	if err := client.Link(ctx, br, embeddedPhrases("br")[1]); err == nil {
+		t.Error("Link: expected error linking phrases of the same language")
+	}
+	if err := client.Link(ctx, br, us); err != nil {
+		t.Fatalf("Link: %v", err)
+	}
+
+	got, err := client.Translation(ctx, br, "us")
+	if err != nil || got != us {
+		t.Errorf("Translation: got %+v (%v), want %+v", got, err, us)
+	}
+
+	phrase, translation, err := client.RandomTranslated(ctx, Options{Language: "us"}, "br")
+	if err != nil || phrase != us || translation != br {
+		t.Errorf("RandomTranslated: got %+v and %+v (%v)", phrase, translation, err)
+	}
+
+	if err = client.Unlink(ctx, us); err != nil {
+		t.Fatalf("Unlink: %v", err)
+	}
+	if _, err = client.Translation(ctx, br, "us"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Translation after Unlink: got %v, want %v", err, ErrNotFound)
+	}
+}
+
+func TestImportTranslationOf(t *testing.T) {
+	br := embeddedPhrases("br")[0]
+	body := `[{"author": "Charles Chaplin", "phrase": "Persistence is the path to success.", "translation_of": "` + br.Phrase + `"}]`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := newTestClient(t)
+
+	if _, err := client.Import(ctx, Source{URL: server.URL, Format: "json", Language: "us"}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	got, err := client.Translation(ctx, br, "us")
+	if err != nil {
+		t.Fatalf("Translation: %v", err)
+	}
+	if got.Phrase != "Persistence is the path to success." {
+		t.Errorf("Translation: got %q", got.Phrase)
+	}
+}
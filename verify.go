@@ -0,0 +1,171 @@
+package motivar
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Signatures are minisign (https://jedisct1.github.io/minisign/) detached
+// signatures, made with:
+//
+//	minisign -S -m quotes.csv
+//
+// which writes quotes.csv.minisig, verified with the public key of
+// minisign.pub.
+
+// ErrVerification is returned by Import when the content doesn't have the
+// pinned SHA-256 digest or a valid signature of a trusted key.
+var ErrVerification = errors.New("verification of the content failed")
+
+var sha256Digest = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+const (
+	// Ed signs the content itself.
+	signatureLegacy = "Ed"
+	// ED signs the BLAKE2b-512 hash of the content.
+	signaturePrehashed = "ED"
+)
+
+// PublicKey is a trusted minisign public key.
+type PublicKey struct {
+	// Name of the key, recorded with the imported content.
+	Name string
+	ID   [8]byte
+	Key  ed25519.PublicKey
+}
+
+// ParsePublicKey parses the minisign public key s, the base64 line of the
+// minisign.pub file or the whole file.
+func ParsePublicKey(name, s string) (PublicKey, error) {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[len(lines)-1]))
+	if err != nil || len(raw) != 2+8+ed25519.PublicKeySize || string(raw[:2]) != signatureLegacy {
+		return PublicKey{}, fmt.Errorf("invalid public key %q: not a minisign ed25519 key", name)
+	}
+
+	key := PublicKey{Name: name, Key: ed25519.PublicKey(raw[10:])}
+	copy(key.ID[:], raw[2:10])
+	return key, nil
+}
+
+// String returns the name and the ID of the key, as shown by minisign.
+func (k PublicKey) String() string {
+	return fmt.Sprintf("%s (%016X)", k.Name, binary.LittleEndian.Uint64(k.ID[:]))
+}
+
+// signature is a parsed .minisig file.
+type signature struct {
+	algorithm      string
+	keyID          [8]byte
+	signature      []byte
+	trustedComment string
+	globalSig      []byte
+}
+
+func parseSignature(b []byte) (signature, error) {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(string(b)), "\n") {
+		lines = append(lines, strings.TrimRight(line, "\r"))
+	}
+	if len(lines) < 4 || !strings.HasPrefix(lines[0], "untrusted comment:") || !strings.HasPrefix(lines[2], "trusted comment: ") {
+		return signature{}, errors.New("invalid signature: not a minisign signature")
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(lines[1])
+	if err != nil || len(raw) != 2+8+ed25519.SignatureSize {
+		return signature{}, errors.New("invalid signature: bad signature line")
+	}
+	global, err := base64.StdEncoding.DecodeString(lines[3])
+	if err != nil || len(global) != ed25519.SignatureSize {
+		return signature{}, errors.New("invalid signature: bad trusted comment signature")
+	}
+
+	sig := signature{
+		algorithm:      string(raw[:2]),
+		signature:      raw[10:],
+		trustedComment: strings.TrimPrefix(lines[2], "trusted comment: "),
+		globalSig:      global,
+	}
+	copy(sig.keyID[:], raw[2:10])
+	return sig, nil
+}
+
+// verifySignature returns the key of keys that made the minisign signature
+// sig of content.
+func verifySignature(content, sig []byte, keys []PublicKey) (PublicKey, error) {
+	s, err := parseSignature(sig)
+	if err != nil {
+		return PublicKey{}, err
+	}
+
+	var key *PublicKey
+	for i := range keys {
+		if keys[i].ID == s.keyID {
+			key = &keys[i]
+			break
+		}
+	}
+	if key == nil {
+		return PublicKey{}, fmt.Errorf("signed by the key %016X, which isn't trusted", binary.LittleEndian.Uint64(s.keyID[:]))
+	}
+
+	message := content
+	switch s.algorithm {
+	case signatureLegacy:
+	case signaturePrehashed:
+		hash := blake2b.Sum512(content)
+		message = hash[:]
+	default:
+		return PublicKey{}, fmt.Errorf("signature algorithm %q not supported", s.algorithm)
+	}
+
+	if !ed25519.Verify(key.Key, message, s.signature) {
+		return PublicKey{}, fmt.Errorf("invalid signature of the key %s", key)
+	}
+	global := bytes.Join([][]byte{s.signature, []byte(s.trustedComment)}, nil)
+	if !ed25519.Verify(key.Key, global, s.globalSig) {
+		return PublicKey{}, fmt.Errorf("invalid trusted comment signature of the key %s", key)
+	}
+	return *key, nil
+}
+
+// verify checks the content of src, whose SHA-256 is hash, with the pinned
+// digest and the signature, if set. It returns the key that signed it, or
+// "" without signature.
+func (c *Client) verify(ctx context.Context, src Source, content []byte, hash string) (string, error) {
+	if src.SHA256 != "" {
+		if !strings.EqualFold(src.SHA256, hash) {
+			return "", fmt.Errorf("%w: the SHA-256 of the content is %s, not %s", ErrVerification, hash, src.SHA256)
+		}
+		c.logger.Info("SHA-256 of the content verified.")
+	}
+
+	if src.SignatureURL == "" {
+		return "", nil
+	}
+	if len(src.TrustedKeys) == 0 {
+		return "", fmt.Errorf("%w: no trusted keys to verify the signature", ErrVerification)
+	}
+
+	c.logger.Info(fmt.Sprintf("Fetching signature %s", src.SignatureURL))
+	sig, _, err := c.fetch(ctx, src.SignatureURL)
+	if err != nil {
+		return "", err
+	}
+	key, err := verifySignature(content, sig, src.TrustedKeys)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrVerification, err)
+	}
+
+	c.logger.Info(fmt.Sprintf("Signature verified with the key %s.", key))
+	return key.String(), nil
+}
@@ -0,0 +1,157 @@
+package motivar
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// minisignKey returns a key pair, and the public key as in minisign.pub.
+func minisignKey(t *testing.T, id byte) (ed25519.PrivateKey, string) {
+	t.Helper()
+
+	seed := bytes.Repeat([]byte{id}, ed25519.SeedSize)
+	private := ed25519.NewKeyFromSeed(seed)
+	raw := append([]byte("Ed"), bytes.Repeat([]byte{id}, 8)...)
+	raw = append(raw, private.Public().(ed25519.PublicKey)...)
+	return private, "untrusted comment: minisign public key\n" + base64.StdEncoding.EncodeToString(raw) + "\n"
+}
+
+// minisign returns the signature of content by the key id, as minisign -S
+// writes it. prehashed uses the ED algorithm, the default of minisign.
+func minisign(private ed25519.PrivateKey, id byte, content []byte, prehashed bool) []byte {
+	algorithm, message := "Ed", content
+	if prehashed {
+		hash := blake2b.Sum512(content)
+		algorithm, message = "ED", hash[:]
+	}
+	sig := ed25519.Sign(private, message)
+	raw := append([]byte(algorithm), bytes.Repeat([]byte{id}, 8)...)
+	raw = append(raw, sig...)
+
+	comment := "timestamp:1700000000\tfile:quotes.csv"
+	global := ed25519.Sign(private, append(sig, comment...))
+	return []byte("untrusted comment: signature from minisign secret key\n" +
+		base64.StdEncoding.EncodeToString(raw) + "\n" +
+		"trusted comment: " + comment + "\n" +
+		base64.StdEncoding.EncodeToString(global) + "\n")
+}
+
+func TestVerifySignature(t *testing.T) {
+	private, public := minisignKey(t, 1)
+	key, err := ParsePublicKey("team", public)
+	if err != nil {
+		t.Fatalf("ParsePublicKey: %v", err)
+	}
+	otherPrivate, _ := minisignKey(t, 2)
+
+	content := []byte("\"Be yourself; everyone else is already taken.\",Oscar Wilde\n")
+	tampered := append(bytes.Clone(content), '!')
+	tests := map[string]struct {
+		content []byte
+		sig     []byte
+		ok      bool
+	}{
+		"legacy":        {content, minisign(private, 1, content, false), true},
+		"prehashed":     {content, minisign(private, 1, content, true), true},
+		"tampered":      {tampered, minisign(private, 1, content, true), false},
+		"untrusted key": {content, minisign(otherPrivate, 2, content, true), false},
+		"wrong key":     {content, minisign(otherPrivate, 1, content, true), false},
+		"not minisign":  {content, []byte("-----BEGIN PGP SIGNATURE-----\n"), false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := verifySignature(tt.content, tt.sig, []PublicKey{key})
+			if !tt.ok {
+				if err == nil {
+					t.Error("want error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != "team (0101010101010101)" {
+				t.Errorf("got key %s", got)
+			}
+		})
+	}
+
+	if _, err := ParsePublicKey("bad", "RWQ="); err == nil {
+		t.Error("ParsePublicKey: want error for an invalid key")
+	}
+}
+
+func TestImportVerified(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	private, public := minisignKey(t, 1)
+	key, err := ParsePublicKey("team", public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile("samples/quotes-us.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "quotes.csv")
+	if err = os.WriteFile(file, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(file+".minisig", minisign(private, 1, content, true), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err = os.WriteFile(file+".bad", minisign(private, 1, []byte("other"), true), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	hash := sha256.Sum256(content)
+	digest := hex.EncodeToString(hash[:])
+
+	client := newTestClient(t)
+	src := Source{URL: file, Format: "csv", Language: "us"}
+
+	refused := map[string]Source{
+		"wrong sha256":    {SHA256: hex.EncodeToString(make([]byte, 32))},
+		"bad signature":   {SignatureURL: file + ".bad", TrustedKeys: []PublicKey{key}},
+		"no trusted keys": {SignatureURL: file + ".minisig"},
+	}
+	for name, s := range refused {
+		s.URL, s.Format, s.Language = src.URL, src.Format, src.Language
+		if _, err := client.Import(ctx, s); !errors.Is(err, ErrVerification) {
+			t.Errorf("%s: got %v, want %v", name, err, ErrVerification)
+		}
+	}
+	if _, err := client.Import(ctx, Source{URL: file, Format: "csv", Language: "us", SHA256: "abc"}); err == nil {
+		t.Error("invalid sha256: want error")
+	}
+
+	src.SHA256 = digest
+	src.SignatureURL = file + ".minisig"
+	src.TrustedKeys = []PublicKey{key}
+	if _, err := client.Import(ctx, src); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	db, err := client.database(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var verifiedKey string
+	err = db.conn.QueryRowContext(ctx, "SELECT verified_key FROM hashes WHERE content_hash = ?", digest).Scan(&verifiedKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if verifiedKey != key.String() {
+		t.Errorf("verified_key: got %q, want %q", verifiedKey, key.String())
+	}
+}
@@ -0,0 +1,259 @@
+package motivar
+
+import (
+	"compress/bzip2"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Wikiquote dumps are at https://dumps.wikimedia.org, like
+// ptwikiquote-latest-pages-articles.xml.bz2. Each page has the quotes of an
+// author in bullet lists:
+//
+//	== Citações ==
+//	* "A quote."
+//	** Source of the quote
+//	== Sobre ==
+//	* A quote about the author, skipped.
+
+// WikiquoteOptions select the quotes imported from a Wikiquote dump.
+type WikiquoteOptions struct {
+	// Language of the quotes, or LanguageAuto to detect it per quote.
+	Language string
+	// Pages are the titles of the pages to import. Empty imports all pages.
+	Pages []string
+	// Categories import only pages in a category containing one of them,
+	// ignoring case. Empty imports all pages.
+	Categories []string
+	// MinLength and MaxLength of the quotes, in characters. Zero is no
+	// limit.
+	MinLength, MaxLength int
+}
+
+// wikiquoteSkipSections have quotes not said by the author of the page, or
+// no quotes. Sections whose title contains one of them are skipped.
+var wikiquoteSkipSections = []string{
+	// English
+	"about", "disputed", "misattributed", "see also", "external links", "references", "sources",
+	// Portuguese
+	"sobre", "atribuídas erroneamente", "disputadas", "ver também", "ligações externas", "referências", "fontes",
+}
+
+var (
+	wikiComment  = regexp.MustCompile(`(?s)<!--.*?-->`)
+	wikiRef      = regexp.MustCompile(`(?is)<ref[^>/]*/>|<ref[^>]*>.*?</ref>`)
+	wikiTag      = regexp.MustCompile(`</?[a-zA-Z][^>]*>`)
+	wikiTemplate = regexp.MustCompile(`\{\{[^{}]*\}\}`)
+	wikiFile     = regexp.MustCompile(`(?i)\[\[(?:file|image|arquivo|imagem|ficheiro):[^\]]*\]\]`)
+	wikiLink     = regexp.MustCompile(`\[\[(?:[^|\]]*\|)?([^\]]*)\]\]`)
+	wikiExtLink  = regexp.MustCompile(`\[(?:https?:)?//[^\s\]]+(?:\s([^\]]*))?\]`)
+	wikiCategory = regexp.MustCompile(`(?i)\[\[(?:category|categoria):([^|\]]+)`)
+	wikiHeading  = regexp.MustCompile(`^(=+)\s*(.*?)\s*=+$`)
+)
+
+// wikiPage is a page of the dump.
+type wikiPage struct {
+	Title     string    `xml:"title"`
+	Namespace int       `xml:"ns"`
+	Redirect  *struct{} `xml:"redirect"`
+	Text      string    `xml:"revision>text"`
+}
+
+// ImportWikiquote imports the quotes of the Wikiquote dump at path, an XML
+// file or an XML file compressed with bzip2. The dump is read as a stream,
+// so it can be bigger than the memory. It returns how many new phrases
+// were saved.
+func (c *Client) ImportWikiquote(ctx context.Context, path string, opts WikiquoteOptions) (int, error) {
+	if opts.Language != LanguageAuto {
+		if err := CheckLanguage(opts.Language); err != nil {
+			return 0, err
+		}
+	}
+
+	db, err := c.database(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	var reader io.Reader = io.TeeReader(file, hash)
+	if strings.HasSuffix(path, ".bz2") {
+		reader = bzip2.NewReader(reader)
+	}
+
+	c.logger.Info(fmt.Sprintf("Reading %s", path))
+	items, err := c.readWikiquote(ctx, reader, opts)
+	if err != nil {
+		return 0, err
+	}
+	// Hash the rest of the file, if the dump has something after the pages.
+	if _, err := io.Copy(hash, file); err != nil {
+		return 0, err
+	}
+
+	r := req{logger: c.logger, BodyHash: hex.EncodeToString(hash.Sum(nil))}
+	return c.save(ctx, db, r, items, path, opts.Language)
+}
+
+// readWikiquote returns the quotes of the pages selected by opts.
+func (c *Client) readWikiquote(ctx context.Context, r io.Reader, opts WikiquoteOptions) ([]sourcePhrase, error) {
+	var (
+		items []sourcePhrase
+		pages int
+	)
+
+	decoder := xml.NewDecoder(r)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid Wikiquote dump: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "page" {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		var page wikiPage
+		if err := decoder.DecodeElement(&page, &start); err != nil {
+			return nil, fmt.Errorf("invalid Wikiquote dump: %w", err)
+		}
+		if !opts.selects(page) {
+			continue
+		}
+
+		quotes := wikiquoteQuotes(page)
+		n := 0
+		for _, q := range quotes {
+			length := utf8.RuneCountInString(q.Phrase)
+			if length < opts.MinLength || (opts.MaxLength > 0 && length > opts.MaxLength) {
+				continue
+			}
+			items = append(items, q)
+			n++
+		}
+		pages++
+		c.logger.Debug(fmt.Sprintf("Page %q: %d quotes", page.Title, n))
+	}
+
+	c.logger.Info(fmt.Sprintf("Found %d quotes in %d pages", len(items), pages))
+	return items, nil
+}
+
+// selects reports whether the quotes of page are imported.
+func (opts WikiquoteOptions) selects(page wikiPage) bool {
+	// Only articles, not talk pages, categories, etc.
+	if page.Namespace != 0 || page.Redirect != nil {
+		return false
+	}
+	if len(opts.Pages) > 0 && !slices.Contains(opts.Pages, page.Title) {
+		return false
+	}
+	if len(opts.Categories) == 0 {
+		return true
+	}
+
+	for _, m := range wikiCategory.FindAllStringSubmatch(page.Text, -1) {
+		category := strings.ToLower(m[1])
+		for _, want := range opts.Categories {
+			if strings.Contains(category, strings.ToLower(want)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// wikiquoteQuotes returns the quotes of the author of page: the first level
+// bullets, with the second level bullet after it as source.
+func wikiquoteQuotes(page wikiPage) []sourcePhrase {
+	var (
+		quotes []sourcePhrase
+		skip   bool
+		// skipLevel is the level of the heading of the skipped section.
+		skipLevel int
+		// last quote, that gets the source.
+		last = -1
+	)
+
+	text := wikiRef.ReplaceAllString(wikiComment.ReplaceAllString(page.Text, ""), "")
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+
+		if m := wikiHeading.FindStringSubmatch(line); m != nil {
+			level, title := len(m[1]), strings.ToLower(cleanWikitext(m[2]))
+			if skip && level > skipLevel {
+				continue
+			}
+			skip = containsAny(title, wikiquoteSkipSections)
+			skipLevel = level
+			last = -1
+			continue
+		}
+		if skip {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "**"):
+			if last >= 0 && quotes[last].Source == "" {
+				quotes[last].Source = cleanWikitext(strings.TrimLeft(line, "*:"))
+			}
+		case strings.HasPrefix(line, "*"):
+			last = -1
+			phrase := cleanWikitext(strings.TrimPrefix(line, "*"))
+			if phrase == "" {
+				continue
+			}
+			quotes = append(quotes, sourcePhrase{Author: page.Title, Phrase: phrase})
+			last = len(quotes) - 1
+		default:
+			last = -1
+		}
+	}
+	return quotes
+}
+
+// cleanWikitext returns the text of wikitext without markup.
+func cleanWikitext(s string) string {
+	s = wikiRef.ReplaceAllString(wikiComment.ReplaceAllString(s, ""), "")
+	// Templates may be nested.
+	for {
+		cleaned := wikiTemplate.ReplaceAllString(s, "")
+		if cleaned == s {
+			break
+		}
+		s = cleaned
+	}
+	s = wikiFile.ReplaceAllString(s, "")
+	s = wikiLink.ReplaceAllString(s, "$1")
+	s = wikiExtLink.ReplaceAllString(s, "$1")
+	s = wikiTag.ReplaceAllString(s, "")
+	s = strings.NewReplacer("'''", "", "''", "").Replace(s)
+	s = html.UnescapeString(s)
+
+	return trimQuotes(strings.Join(strings.Fields(s), " "))
+}
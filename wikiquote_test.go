@@ -0,0 +1,105 @@
+package motivar
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadWikiquote(t *testing.T) {
+	clarice := []sourcePhrase{
+		{Author: "Clarice Lispector", Phrase: "Que ninguém se engane, só se consegue a simplicidade através de muito trabalho.", Source: "A Hora da Estrela, 1977"},
+		{Author: "Clarice Lispector", Phrase: "Liberdade é pouco. O que eu desejo ainda não tem nome.", Source: "Perto do Coração Selvagem"},
+		{Author: "Clarice Lispector", Phrase: "Mude, mas comece devagar, porque a direção é mais importante que a velocidade."},
+	}
+	machado := sourcePhrase{Author: "Machado de Assis", Phrase: "Ao vencedor, as batatas!", Source: "Quincas Borba"}
+
+	tests := map[string]struct {
+		opts WikiquoteOptions
+		want []sourcePhrase
+	}{
+		"min length": {
+			opts: WikiquoteOptions{MinLength: 10, Categories: []string{"escritores"}},
+			want: append(slices.Clone(clarice), machado),
+		},
+		"max length": {
+			opts: WikiquoteOptions{MaxLength: 30, Categories: []string{"Escritores do Brasil"}},
+			want: []sourcePhrase{{Author: "Clarice Lispector", Phrase: "Sim."}, machado},
+		},
+		"pages": {
+			opts: WikiquoteOptions{MinLength: 10, Pages: []string{"Clarice Lispector", "Lispector"}},
+			want: clarice,
+		},
+	}
+
+	client := newTestClient(t)
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			file, err := os.Open("samples/wikiquote-pt.xml")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+
+			got, err := client.readWikiquote(context.Background(), file, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanWikitext(t *testing.T) {
+	tests := map[string]string{
+		`'''Bold''' and ''italic''`:                      "Bold and italic",
+		`[[w:Page|label]] and [[Page]]`:                  "label and Page",
+		`text{{template|{{nested}}}} end`:                "text end",
+		`[https://example.com site] and [//x.org]`:       "site and",
+		`“Quote”<ref name="a">source</ref><ref name=b/>`: "Quote",
+		`a<br />b [[File:X.jpg|thumb|x]]`:                "ab",
+		`&quot;x&quot; &amp; y`:                          `x" & y`,
+	}
+	for in, want := range tests {
+		if got := cleanWikitext(in); got != want {
+			t.Errorf("cleanWikitext(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestImportWikiquote(t *testing.T) {
+	ctx := context.Background()
+	client := newTestClient(t)
+	opts := WikiquoteOptions{Language: "br", MinLength: 10, Categories: []string{"escritores"}}
+
+	inserted, err := client.ImportWikiquote(ctx, "samples/wikiquote-pt.xml.bz2", opts)
+	if err != nil {
+		t.Fatalf("ImportWikiquote: %v", err)
+	}
+	if inserted != 4 {
+		t.Errorf("ImportWikiquote: got %d phrases, want 4", inserted)
+	}
+
+	found, err := client.Search(ctx, "batatas", Options{Language: "br"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 1 || found[0].Author != "Machado de Assis" || found[0].Source != "Quincas Borba" {
+		t.Errorf("Search: got %+v", found)
+	}
+
+	_, err = client.ImportWikiquote(ctx, "samples/wikiquote-pt.xml.bz2", opts)
+	if !errors.Is(err, ErrContentExists) {
+		t.Errorf("second ImportWikiquote: got %v, want ErrContentExists", err)
+	}
+
+	_, err = client.ImportWikiquote(ctx, "samples/quotes-us.json", opts)
+	if err == nil || !strings.Contains(err.Error(), "no phrases") {
+		t.Errorf("ImportWikiquote of JSON: got %v", err)
+	}
+}